@@ -0,0 +1,3 @@
+data "ukc_autoscale_configuration" "example" {
+  service_group_uuid = "01234567-89ab-cdef-0123-456789abcdef"
+}
@@ -0,0 +1,9 @@
+data "ukc_image" "example" {
+  tag = "nginx:latest"
+}
+
+resource "ukc_instance" "example" {
+  # Pin the instance to the digest the tag resolved to at plan time.
+  image     = "nginx@${data.ukc_image.example.digest}"
+  memory_mb = 128
+}
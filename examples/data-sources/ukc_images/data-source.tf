@@ -0,0 +1,3 @@
+data "ukc_images" "example" {
+  tags = ["nginx:latest", "caddy:latest"]
+}
@@ -1,3 +1,11 @@
 provider "ukc" {
   metro = "fra0"
 }
+
+# Resources and data sources are located in the metro of the provider unless
+# they set their own.
+resource "ukc_instance" "sfo" {
+  metro     = "sfo0"
+  image     = "nginx:latest"
+  memory_mb = 128
+}
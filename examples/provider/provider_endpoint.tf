@@ -0,0 +1,5 @@
+provider "ukc" {
+  endpoint     = "https://ukc.example.internal/{metro}"
+  ca_cert_file = "/etc/ssl/certs/example-internal-ca.pem"
+  proxy_url    = "http://proxy.example.internal:3128"
+}
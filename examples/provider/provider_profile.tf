@@ -0,0 +1,4 @@
+provider "ukc" {
+  config_file = "~/.config/kraftkit/config.yaml"
+  profile     = "index.unikraft.io"
+}
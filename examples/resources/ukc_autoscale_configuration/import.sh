@@ -0,0 +1 @@
+terraform import ukc_autoscale_configuration.example <service-group-uuid>
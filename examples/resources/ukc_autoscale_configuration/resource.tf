@@ -0,0 +1,24 @@
+resource "ukc_autoscale_configuration" "example" {
+  service_group_uuid = "01234567-89ab-cdef-0123-456789abcdef"
+  min_size           = 1
+  max_size           = 8
+  warmup_time_ms     = 1000
+  cooldown_time_ms   = 10000
+  policies = [
+    {
+      name            = "cpu-steps"
+      metric          = "cpu"
+      adjustment_type = "change"
+      steps = [
+        {
+          adjustment  = -1
+          upper_bound = 20
+        },
+        {
+          adjustment  = 2
+          lower_bound = 80
+        }
+      ]
+    }
+  ]
+}
@@ -1 +1,9 @@
+# Instances can be imported by UUID
 terraform import ukc_instance.example 01234567-89ab-cdef-0123-456789abcdef
+
+# or by name
+terraform import ukc_instance.example my-instance
+
+# Instances outside of the metro of the provider are imported by prefixing
+# their UUID or name with the metro
+terraform import ukc_instance.example sfo0/my-instance
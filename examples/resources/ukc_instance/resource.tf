@@ -1,7 +1,31 @@
 resource "ukc_instance" "example" {
-  image     = "myuser.unikraft.io/myapp:latest"
-  memory_mb = 64
-  autostart = true
+  name           = "my-instance"
+  image          = "myuser.unikraft.io/myapp:latest"
+  memory_mb      = 64
+  vcpus          = 1
+  autostart      = true
+  restart_policy = "on-failure"
+
+  # Redeploy whenever the tag is pushed again.
+  track_image_updates = true
+
+  env = {
+    LOG_LEVEL = "info"
+  }
+  secret_env = {
+    DB_PASSWORD = var.db_password
+  }
+  scale_to_zero = {
+    policy           = "on"
+    stateful         = true
+    cooldown_time_ms = 1000
+  }
+  volumes = [
+    {
+      name = "my-volume"
+      at   = "/data"
+    }
+  ]
   service_group = {
     services = [
       {
@@ -9,5 +33,13 @@ resource "ukc_instance" "example" {
         handlers = ["http"]
       }
     ]
+    domains = [
+      {
+        name = "example.com."
+        certificate = {
+          name = "my-certificate"
+        }
+      }
+    ]
   }
 }
@@ -0,0 +1 @@
+terraform import ukc_service_group.example <service-group-uuid>
@@ -0,0 +1,23 @@
+resource "ukc_service_group" "example" {
+  name = "my-service-group"
+  services = [
+    {
+      port             = 443
+      destination_port = 8080
+      handlers         = ["tls", "http"]
+    }
+  ]
+  domains = [
+    {
+      name = "my-app"
+    }
+  ]
+}
+
+# Changing the image of these instances rolls out new instances in the same
+# service group before the old ones are deleted, without interrupting traffic.
+resource "ukc_instance" "backend" {
+  count              = 2
+  image              = "myuser.unikraft.io/myapp:latest"
+  service_group_uuid = ukc_service_group.example.uuid
+}
@@ -0,0 +1 @@
+terraform import ukc_volume_attachment.example <volume-uuid>/<instance-uuid>
@@ -0,0 +1,6 @@
+resource "ukc_volume_attachment" "example" {
+  volume_uuid   = ukc_volume.example.uuid
+  instance_uuid = ukc_instance.example.uuid
+  at            = "/data"
+  read_only     = false
+}
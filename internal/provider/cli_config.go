@@ -0,0 +1,91 @@
+// Copyright (c) Unikraft GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultCLIProfile is the entry of the kraft CLI configuration file the
+// credentials are read from when no profile is set. The kraft CLI stores the
+// credentials of Unikraft Cloud under the hostname of its registry.
+const defaultCLIProfile = "index.unikraft.io"
+
+// errCLIProfileNotFound is returned when the requested profile does not exist
+// in the kraft CLI configuration file.
+var errCLIProfileNotFound = errors.New("profile not found")
+
+// cliConfig is the subset of the kraft CLI configuration file the provider
+// reads its credentials from.
+type cliConfig struct {
+	Auth map[string]cliProfile `yaml:"auth"`
+}
+
+// cliProfile holds the credentials of a profile of the kraft CLI
+// configuration file.
+type cliProfile struct {
+	User  string `yaml:"user"`
+	Token string `yaml:"token"`
+	Metro string `yaml:"metro"`
+}
+
+// APIToken returns the token used to authenticate against the API, which is
+// derived from the user and token of the profile the same way the kraft CLI
+// does.
+func (p cliProfile) APIToken() string {
+	if p.Token == "" {
+		return ""
+	}
+	if p.User == "" {
+		return p.Token
+	}
+	return base64.StdEncoding.EncodeToString([]byte(p.User + ":" + p.Token))
+}
+
+// defaultCLIConfigFile returns the location of the kraft CLI configuration
+// file in the configuration directory of the user, or an empty string if that
+// directory cannot be determined.
+func defaultCLIConfigFile() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "kraftkit", "config.yaml")
+}
+
+// loadCLIProfile reads the given profile from the kraft CLI configuration
+// file at path. A leading "~" in path refers to the home directory of the
+// user.
+func loadCLIProfile(path, profile string) (*cliProfile, error) {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		path = filepath.Join(home, rest)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg cliConfig
+	if err := yaml.Unmarshal(b, &cfg); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	p, ok := cfg.Auth[profile]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q in %s", errCLIProfileNotFound, profile, path)
+	}
+	return &p, nil
+}
@@ -0,0 +1,65 @@
+// Copyright (c) Unikraft GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadCLIProfile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	err := os.WriteFile(path, []byte(`
+auth:
+  index.unikraft.io:
+    endpoint: index.unikraft.io
+    user: robot$me.users.kraftcloud
+    token: secret
+  staging:
+    token: c3RhZ2luZw==
+    metro: sfo0
+`), 0o600)
+	assert.NoError(t, err)
+
+	p, err := loadCLIProfile(path, defaultCLIProfile)
+	assert.NoError(t, err)
+	assert.Equal(t, "cm9ib3QkbWUudXNlcnMua3JhZnRjbG91ZDpzZWNyZXQ=", p.APIToken())
+	assert.Empty(t, p.Metro)
+
+	p, err = loadCLIProfile(path, "staging")
+	assert.NoError(t, err)
+	assert.Equal(t, "c3RhZ2luZw==", p.APIToken())
+	assert.Equal(t, "sfo0", p.Metro)
+
+	_, err = loadCLIProfile(path, "missing")
+	assert.ErrorIs(t, err, errCLIProfileNotFound)
+
+	_, err = loadCLIProfile(filepath.Join(t.TempDir(), "missing.yaml"), defaultCLIProfile)
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+
+	t.Setenv("HOME", filepath.Dir(path))
+	_, err = loadCLIProfile("~/config.yaml", "staging")
+	assert.NoError(t, err)
+}
+
+func TestConfigValue(t *testing.T) {
+	t.Setenv("UKC_TEST_VALUE", "from-env")
+
+	v, source := configValue(types.StringValue("from-config"), "UKC_TEST_VALUE")
+	assert.Equal(t, "from-config", v)
+	assert.Equal(t, "provider configuration", source)
+
+	v, source = configValue(types.StringNull(), "UKC_TEST_VALUE")
+	assert.Equal(t, "from-env", v)
+	assert.Equal(t, "UKC_TEST_VALUE environment variable", source)
+
+	v, source = configValue(types.StringNull(), "UKC_TEST_UNSET")
+	assert.Empty(t, v)
+	assert.Empty(t, source)
+}
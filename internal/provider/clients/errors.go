@@ -0,0 +1,64 @@
+// Copyright (c) Unikraft GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+
+	"unikraft.com/cloud/sdk/platform"
+)
+
+// APIError is implemented by the responses of the platform API, which are
+// returned as errors by the client when a request did not succeed.
+//
+// RawBody drains the buffer holding the body of the response, so that a second
+// call returns nothing. Use DecodeAPIError instead, so that the outcome of a
+// check does not depend on whether the error was inspected before.
+type APIError interface {
+	error
+	RawBody() []byte
+}
+
+// APIErrorBody describes the parts of an API response body that carry error
+// codes. Objects in the data element are decoded loosely, because the name of
+// the array depends on the type of object the request operated on.
+type APIErrorBody struct {
+	Errors []platform.ResponseError   `json:"errors"`
+	Data   map[string]json.RawMessage `json:"data"`
+}
+
+// HasStatus reports whether one of the errors of the body carries one of the
+// given HTTP statuses.
+func (b *APIErrorBody) HasStatus(statuses ...int) bool {
+	for _, e := range b.Errors {
+		if e.Status != nil && slices.Contains(statuses, int(*e.Status)) {
+			return true
+		}
+	}
+	return false
+}
+
+// DecodeAPIError decodes the body of the response of the platform API wrapped
+// by err, and reports whether err wraps such a response. The response is
+// encoded again from the fields the client decoded it into, rather than read
+// with RawBody.
+func DecodeAPIError(err error) (*APIErrorBody, bool) {
+	var apiErr APIError
+	if !errors.As(err, &apiErr) {
+		return nil, false
+	}
+
+	b, err := json.Marshal(apiErr)
+	if err != nil {
+		return nil, false
+	}
+
+	var body APIErrorBody
+	if err := json.Unmarshal(b, &body); err != nil {
+		return nil, false
+	}
+	return &body, true
+}
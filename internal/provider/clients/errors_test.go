@@ -0,0 +1,49 @@
+// Copyright (c) Unikraft GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"unikraft.com/cloud/sdk/platform"
+)
+
+func TestDecodeAPIError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"status":"error","data":{"instances":[{"status":"error","error":8}]},"errors":[{"status":404}]}`))
+	}))
+	defer srv.Close()
+
+	client := platform.NewClient(platform.WithHTTPClient(srv.Client())).WithMetro(srv.URL)
+	_, err := client.GetInstanceByUUID(context.Background(), "uuid", false)
+	require.Error(t, err)
+
+	// The result does not depend on the body of the response having been
+	// read before.
+	var apiErr APIError
+	require.ErrorAs(t, err, &apiErr)
+	apiErr.RawBody()
+
+	for range 2 {
+		body, ok := DecodeAPIError(err)
+		require.True(t, ok)
+		assert.True(t, body.HasStatus(http.StatusNotFound))
+		assert.False(t, body.HasStatus(http.StatusUnauthorized, http.StatusForbidden))
+		assert.Contains(t, body.Data, "instances")
+	}
+
+	_, ok := DecodeAPIError(errors.New("404 not found"))
+	assert.False(t, ok)
+	_, ok = DecodeAPIError(nil)
+	assert.False(t, ok)
+}
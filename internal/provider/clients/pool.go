@@ -0,0 +1,80 @@
+// Copyright (c) Unikraft GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+// Package clients provides the Unikraft Cloud API clients shared by the
+// resources and data sources of the provider.
+package clients
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"unikraft.com/cloud/sdk/platform"
+)
+
+// Pool hands out API clients keyed by metro. All clients share the options of
+// the base client the pool was created with, and only differ by the metro
+// they send requests to.
+type Pool struct {
+	base         platform.Client
+	defaultMetro string
+	endpoint     string
+
+	mu      sync.Mutex
+	clients map[string]platform.Client
+}
+
+// NewPool returns a Pool deriving its clients from base. Requests which do not
+// specify a metro are sent to defaultMetro.
+//
+// Unless endpoint is empty, requests are sent to the API at the endpoint URL
+// rather than to the well-known URL of their metro. Occurrences of "{metro}"
+// in the endpoint are replaced by the metro of the request.
+func NewPool(base platform.Client, defaultMetro, endpoint string) *Pool {
+	return &Pool{
+		base:         base,
+		defaultMetro: defaultMetro,
+		endpoint:     endpoint,
+		clients:      make(map[string]platform.Client),
+	}
+}
+
+// Resolve returns the metro requests for the given metro are sent to, i.e.
+// metro itself or the default metro of the pool if metro is empty.
+func (p *Pool) Resolve(metro string) string {
+	if metro == "" {
+		return p.defaultMetro
+	}
+	return metro
+}
+
+// Get returns the client for the given metro, or for the default metro of the
+// pool if metro is empty. Clients are created on first use.
+func (p *Pool) Get(metro string) platform.Client {
+	metro = p.Resolve(metro)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	c, ok := p.clients[metro]
+	if !ok {
+		// The SDK treats metros containing a scheme as the URL of the API.
+		target := metro
+		if p.endpoint != "" {
+			target = strings.ReplaceAll(p.endpoint, "{metro}", metro)
+		}
+		c = p.base.WithMetro(target)
+		p.clients[metro] = c
+	}
+	return c
+}
+
+// Client returns the client for the metro attribute of a resource or data
+// source. A null or unknown metro is resolved in place to the default metro
+// of the pool, so that the metro requests are sent to is stored in state.
+func (p *Pool) Client(metro *types.String) platform.Client {
+	*metro = types.StringValue(p.Resolve(metro.ValueString()))
+	return p.Get(metro.ValueString())
+}
@@ -0,0 +1,62 @@
+// Copyright (c) Unikraft GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+
+	"unikraft.com/cloud/sdk/platform"
+)
+
+func TestPool(t *testing.T) {
+	p := NewPool(platform.NewClient(platform.WithToken("token")), "fra0", "")
+
+	assert.Equal(t, "fra0", p.Resolve(""))
+	assert.Equal(t, "sfo0", p.Resolve("sfo0"))
+
+	assert.Same(t, p.Get(""), p.Get("fra0"))
+	assert.Same(t, p.Get("sfo0"), p.Get("sfo0"))
+	assert.NotSame(t, p.Get("fra0"), p.Get("sfo0"))
+}
+
+func TestPool_Client(t *testing.T) {
+	p := NewPool(platform.NewClient(platform.WithToken("token")), "fra0", "")
+
+	metro := types.StringNull()
+	assert.Same(t, p.Get("fra0"), p.Client(&metro))
+	assert.Equal(t, types.StringValue("fra0"), metro)
+
+	metro = types.StringUnknown()
+	p.Client(&metro)
+	assert.Equal(t, types.StringValue("fra0"), metro)
+
+	metro = types.StringValue("sfo0")
+	assert.Same(t, p.Get("sfo0"), p.Client(&metro))
+	assert.Equal(t, types.StringValue("sfo0"), metro)
+}
+
+func TestPool_Endpoint(t *testing.T) {
+	var got []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"success","data":{"instances":[]}}`))
+	}))
+	defer srv.Close()
+
+	p := NewPool(platform.NewClient(platform.WithToken("token")), "fra0", srv.URL+"/{metro}")
+
+	_, err := p.Get("").GetInstances(context.Background(), nil, false)
+	assert.NoError(t, err)
+	_, err = p.Get("sfo0").GetInstances(context.Background(), nil, false)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"/fra0/v1/instances", "/sfo0/v1/instances"}, got)
+}
@@ -0,0 +1,242 @@
+// Copyright (c) Unikraft GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package datasource
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/unikraft-cloud/terraform-provider-unikraft-cloud/internal/provider/clients"
+	models "github.com/unikraft-cloud/terraform-provider-unikraft-cloud/internal/provider/model"
+
+	"unikraft.com/cloud/sdk/platform"
+)
+
+func NewAutoscaleConfigurationDataSource() datasource.DataSource {
+	return &AutoscaleConfigurationDataSource{}
+}
+
+// AutoscaleConfigurationDataSource defines the data source implementation.
+type AutoscaleConfigurationDataSource struct {
+	clients *clients.Pool
+}
+
+// Ensure AutoscaleConfigurationDataSource satisfies various datasource interfaces.
+var _ datasource.DataSource = &AutoscaleConfigurationDataSource{}
+
+// AutoscaleConfigurationDataSourceModel describes the data source data model.
+type AutoscaleConfigurationDataSourceModel struct {
+	Metro types.String `tfsdk:"metro"`
+
+	models.AutoscaleConfigurationModel
+
+	Enabled types.Bool `tfsdk:"enabled"`
+}
+
+// Metadata implements datasource.DataSource.
+func (d *AutoscaleConfigurationDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_autoscale_configuration"
+}
+
+// Schema implements datasource.DataSource.
+func (d *AutoscaleConfigurationDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Provides the autoscale configuration of a Unikraft Cloud service group.",
+
+		Attributes: map[string]schema.Attribute{
+			"metro": metroAttribute(),
+			"service_group_uuid": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "UUID of the service group.",
+			},
+			"enabled": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether autoscaling is enabled for the service group.",
+			},
+			"min_size": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Minimum number of instances in the service group.",
+			},
+			"max_size": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Maximum number of instances in the service group.",
+			},
+			"warmup_time_ms": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Time in milliseconds given to a new instance to start before it is taken into account for scaling decisions.",
+			},
+			"cooldown_time_ms": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Time in milliseconds to wait after a scaling action before scaling again.",
+			},
+			"template_instance_uuid": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "UUID of the instance which new instances are created from.",
+			},
+			"policies": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Scaling policies of the configuration.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Name of the policy.",
+						},
+						"enabled": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the policy is enabled.",
+						},
+						"metric": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Metric the policy reacts to.",
+						},
+						"adjustment_type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "How the adjustment of a step is applied (change, exact, percentage).",
+						},
+						"steps": schema.ListNestedAttribute{
+							Computed:            true,
+							MarkdownDescription: "Steps of the policy.",
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"adjustment": schema.Int64Attribute{
+										Computed:            true,
+										MarkdownDescription: "Adjustment applied to the number of instances.",
+									},
+									"lower_bound": schema.Int64Attribute{
+										Computed:            true,
+										MarkdownDescription: "Lower bound of the metric for this step.",
+									},
+									"upper_bound": schema.Int64Attribute{
+										Computed:            true,
+										MarkdownDescription: "Upper bound of the metric for this step.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure implements datasource.DataSource.
+func (d *AutoscaleConfigurationDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	pool, ok := req.ProviderData.(*clients.Pool)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *clients.Pool, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.clients = pool
+}
+
+// Read implements datasource.DataSource.
+func (d *AutoscaleConfigurationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AutoscaleConfigurationDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := d.clients.Client(&data.Metro)
+
+	asResp, err := client.GetAutoscaleConfigurationsByServiceGroupUUID(ctx, data.ServiceGroupUUID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Failed to get autoscale configuration, got error: %v", err),
+		)
+		return
+	}
+
+	if asResp == nil || asResp.Data == nil || len(asResp.Data.ServiceGroups) == 0 {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			"Empty response from get autoscale configuration API",
+		)
+		return
+	}
+	sg := asResp.Data.ServiceGroups[0]
+
+	if sg.Error != nil && !autoscaleUnconfigured(sg) {
+		msg := "unknown error"
+		if sg.Message != nil {
+			msg = *sg.Message
+		}
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Failed to get autoscale configuration, got error: %s", msg),
+		)
+		return
+	}
+
+	readAutoscaleConfiguration(sg, &data)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// readAutoscaleConfiguration populates the model from the autoscale
+// configuration reported by the API. A service group without autoscale
+// configuration is reported as disabled, without policies.
+func readAutoscaleConfiguration(sg platform.GetAutoscaleConfigurationsResponseServiceGroup, data *AutoscaleConfigurationDataSourceModel) {
+	data.Enabled = types.BoolValue(!autoscaleUnconfigured(sg) && (sg.Enabled == nil || *sg.Enabled))
+	data.MinSize = types.Int64PointerValue(sg.MinSize)
+	data.MaxSize = types.Int64PointerValue(sg.MaxSize)
+	data.WarmupTimeMs = types.Int64PointerValue(sg.WarmupTimeMs)
+	data.CooldownTimeMs = types.Int64PointerValue(sg.CooldownTimeMs)
+
+	data.TemplateInstanceUUID = types.StringNull()
+	if sg.Template != nil && sg.Template.Uuid != nil {
+		data.TemplateInstanceUUID = types.StringValue(*sg.Template.Uuid)
+	}
+
+	data.Policies = make([]models.AutoscalePolicyModel, len(sg.Policies))
+	for i, p := range sg.Policies {
+		policy := models.AutoscalePolicyModel{
+			Name:           types.StringPointerValue(p.Name),
+			Enabled:        types.BoolPointerValue(p.Enabled),
+			Metric:         types.StringNull(),
+			AdjustmentType: types.StringNull(),
+			Steps:          make([]models.AutoscaleStepModel, len(p.Steps)),
+		}
+		if p.Metric != nil {
+			policy.Metric = types.StringValue(string(*p.Metric))
+		}
+		if p.AdjustmentType != nil {
+			policy.AdjustmentType = types.StringValue(string(*p.AdjustmentType))
+		}
+		for j, s := range p.Steps {
+			policy.Steps[j] = models.AutoscaleStepModel{
+				Adjustment: types.Int64PointerValue(s.Adjustment),
+				LowerBound: types.Int64PointerValue(s.LowerBound),
+				UpperBound: types.Int64PointerValue(s.UpperBound),
+			}
+		}
+		data.Policies[i] = policy
+	}
+}
+
+// autoscaleUnconfigured reports whether the platform reported the service
+// group as having no autoscale configuration.
+func autoscaleUnconfigured(sg platform.GetAutoscaleConfigurationsResponseServiceGroup) bool {
+	return sg.Status != nil &&
+		string(*sg.Status) == string(platform.GetAutoscaleConfigurationsResponseStatusUnconfigured)
+}
@@ -0,0 +1,259 @@
+// Copyright (c) Unikraft GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package datasource
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/unikraft-cloud/terraform-provider-unikraft-cloud/internal/provider/clients"
+	models "github.com/unikraft-cloud/terraform-provider-unikraft-cloud/internal/provider/model"
+
+	"unikraft.com/cloud/sdk/platform"
+)
+
+func NewImageDataSource() datasource.DataSource {
+	return &ImageDataSource{}
+}
+
+// ImageDataSource defines the data source implementation.
+type ImageDataSource struct {
+	clients *clients.Pool
+}
+
+// Ensure ImageDataSource satisfies various datasource interfaces.
+var _ datasource.DataSource = &ImageDataSource{}
+
+// ImageDataSourceModel describes the data source data model.
+type ImageDataSourceModel struct {
+	Metro types.String `tfsdk:"metro"`
+
+	models.ImageModel
+}
+
+// Metadata implements datasource.DataSource.
+func (d *ImageDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_image"
+}
+
+// Schema implements datasource.DataSource.
+func (d *ImageDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attrs := imageAttributes()
+	attrs["tag"] = schema.StringAttribute{
+		Optional:            true,
+		Computed:            true,
+		MarkdownDescription: "Tag of the image to look up, e.g. `nginx:latest`. Exactly one of `tag` or `digest` must be set.",
+		Validators: []validator.String{
+			stringvalidator.ExactlyOneOf(path.MatchRoot("digest")),
+		},
+	}
+	attrs["digest"] = schema.StringAttribute{
+		Optional:            true,
+		Computed:            true,
+		MarkdownDescription: "Digest of the image to look up, e.g. `sha256:...`. Exactly one of `tag` or `digest` must be set.",
+	}
+	attrs["metro"] = metroAttribute()
+
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Provides information about an image of the Unikraft Cloud registry. " +
+			"Reading this data source fails if the image does not exist, which allows " +
+			"validating the image of an instance at plan time.",
+
+		Attributes: attrs,
+	}
+}
+
+// Configure implements datasource.DataSource.
+func (d *ImageDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	pool, ok := req.ProviderData.(*clients.Pool)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *clients.Pool, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.clients = pool
+}
+
+// Read implements datasource.DataSource.
+func (d *ImageDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ImageDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := d.clients.Client(&data.Metro)
+
+	var (
+		imgResp *platform.Response[platform.GetImageResponseData]
+		ref     string
+		err     error
+	)
+	if !data.Digest.IsNull() {
+		ref = data.Digest.ValueString()
+		imgResp, err = client.GetImageByDigest(ctx, ref)
+	} else {
+		ref = data.Tag.ValueString()
+		imgResp, err = client.GetImageByTag(ctx, ref)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Failed to get image %s, got error: %v", ref, err),
+		)
+		return
+	}
+
+	if imgResp == nil || imgResp.Data == nil || imgResp.Data.Image == nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			"Empty response from get image API",
+		)
+		return
+	}
+
+	tag, digest := data.Tag, data.Digest
+	resp.Diagnostics.Append(readImage(ctx, imgResp.Data.Image, &data.ImageModel)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The platform reports fully qualified references, keep the one the
+	// image was looked up by as configured.
+	if !tag.IsNull() {
+		data.Tag = tag
+	}
+	if !digest.IsNull() {
+		data.Digest = digest
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// imageAttributes returns the computed attributes describing an image. The
+// "tag" and "digest" attributes are left to the caller, as whether they are
+// configurable depends on the data source.
+func imageAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"description": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Description of the image.",
+		},
+		"created_at": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Time when the image was created.",
+		},
+		"arch": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Architecture of the kernel of the image.",
+		},
+		"entrypoint": schema.ListAttribute{
+			Computed:            true,
+			ElementType:         types.StringType,
+			MarkdownDescription: "Default entrypoint of the image.",
+		},
+		"args": schema.ListAttribute{
+			Computed:            true,
+			ElementType:         types.StringType,
+			MarkdownDescription: "Default arguments passed to the entrypoint of the image.",
+		},
+		"env": schema.MapAttribute{
+			Computed:            true,
+			ElementType:         types.StringType,
+			MarkdownDescription: "Default environment variables of the image.",
+		},
+		"ports": schema.ListAttribute{
+			Computed:            true,
+			ElementType:         types.StringType,
+			MarkdownDescription: "Ports documented by the image.",
+		},
+		"labels": schema.MapAttribute{
+			Computed:            true,
+			ElementType:         types.StringType,
+			MarkdownDescription: "Labels of the image.",
+		},
+		"workdir": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Default working directory of the image.",
+		},
+		"kernel_digest": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Digest of the kernel of the image.",
+		},
+		"size_bytes": schema.Int64Attribute{
+			Computed:            true,
+			MarkdownDescription: "Size of the image in bytes, i.e. the size of its kernel and auxiliary ROMs.",
+		},
+	}
+}
+
+// readImage populates the model from the image reported by the API.
+func readImage(ctx context.Context, img *platform.GetImageResponseDataImage, data *models.ImageModel) diag.Diagnostics {
+	var diags, d diag.Diagnostics
+
+	data.Tag = types.StringPointerValue(img.Tag)
+	data.Digest = types.StringPointerValue(img.Digest)
+	data.Description = types.StringPointerValue(img.Description)
+	data.Arch = types.StringPointerValue(img.Arch)
+	data.Workdir = types.StringPointerValue(img.Workdir)
+
+	data.CreatedAt = types.StringNull()
+	if img.CreatedAt != nil {
+		data.CreatedAt = types.StringValue(img.CreatedAt.Format("2006-01-02T15:04:05.999999999Z07:00"))
+	}
+
+	data.Entrypoint, d = types.ListValueFrom(ctx, types.StringType, img.Entrypoint)
+	diags.Append(d...)
+	data.Args, d = types.ListValueFrom(ctx, types.StringType, img.Cmd)
+	diags.Append(d...)
+	data.Ports, d = types.ListValueFrom(ctx, types.StringType, img.Ports)
+	diags.Append(d...)
+	data.Labels, d = types.MapValueFrom(ctx, types.StringType, img.Labels)
+	diags.Append(d...)
+
+	env := make(map[string]string, len(img.Env))
+	for _, kv := range img.Env {
+		k, v, _ := strings.Cut(kv, "=")
+		env[k] = v
+	}
+	data.Env, d = types.MapValueFrom(ctx, types.StringType, env)
+	diags.Append(d...)
+
+	var size int64
+	data.KernelDigest = types.StringNull()
+	if img.Kernel != nil {
+		data.KernelDigest = types.StringPointerValue(img.Kernel.Digest)
+		if img.Kernel.Size != nil {
+			size += *img.Kernel.Size
+		}
+	}
+	for _, rom := range img.AuxiliaryRoms {
+		if rom.Size != nil {
+			size += *rom.Size
+		}
+	}
+	data.SizeBytes = types.Int64Value(size)
+
+	return diags
+}
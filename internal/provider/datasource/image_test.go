@@ -0,0 +1,41 @@
+// Copyright (c) Unikraft GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package datasource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	models "github.com/unikraft-cloud/terraform-provider-unikraft-cloud/internal/provider/model"
+
+	"unikraft.com/cloud/sdk/platform"
+)
+
+func TestReadImage(t *testing.T) {
+	tag, digest, arch := "nginx:latest", "sha256:abc", "x86_64"
+	kernelSize, romSize := int64(1024), int64(512)
+	img := &platform.GetImageResponseDataImage{
+		Tag:           &tag,
+		Digest:        &digest,
+		Arch:          &arch,
+		Cmd:           []string{"-c", "/etc/nginx/nginx.conf"},
+		Env:           []string{"PATH=/usr/bin", "EMPTY=", "NOVALUE"},
+		Kernel:        &platform.ImageKernel{Size: &kernelSize},
+		AuxiliaryRoms: []platform.Object{{Size: &romSize}, {}},
+	}
+
+	var data models.ImageModel
+	diags := readImage(context.Background(), img, &data)
+
+	assert.False(t, diags.HasError())
+	assert.Equal(t, digest, data.Digest.ValueString())
+	assert.Equal(t, arch, data.Arch.ValueString())
+	assert.Len(t, data.Args.Elements(), 2)
+	assert.Len(t, data.Env.Elements(), 3)
+	assert.Equal(t, `"/usr/bin"`, data.Env.Elements()["PATH"].String())
+	assert.Equal(t, int64(1536), data.SizeBytes.ValueInt64())
+	assert.True(t, data.Description.IsNull())
+	assert.True(t, data.KernelDigest.IsNull())
+}
@@ -0,0 +1,142 @@
+// Copyright (c) Unikraft GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package datasource
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/unikraft-cloud/terraform-provider-unikraft-cloud/internal/provider/clients"
+	models "github.com/unikraft-cloud/terraform-provider-unikraft-cloud/internal/provider/model"
+)
+
+func NewImagesDataSource() datasource.DataSource {
+	return &ImagesDataSource{}
+}
+
+// ImagesDataSource defines the data source implementation.
+type ImagesDataSource struct {
+	clients *clients.Pool
+}
+
+// Ensure ImagesDataSource satisfies various datasource interfaces.
+var _ datasource.DataSource = &ImagesDataSource{}
+
+// ImagesDataSourceModel describes the data source data model.
+type ImagesDataSourceModel struct {
+	Metro types.String `tfsdk:"metro"`
+
+	Tags   types.List          `tfsdk:"tags"`
+	Images []models.ImageModel `tfsdk:"images"`
+}
+
+// Metadata implements datasource.DataSource.
+func (d *ImagesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_images"
+}
+
+// Schema implements datasource.DataSource.
+func (d *ImagesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attrs := imageAttributes()
+	attrs["tag"] = schema.StringAttribute{
+		Computed:            true,
+		MarkdownDescription: "Tag of the image.",
+	}
+	attrs["digest"] = schema.StringAttribute{
+		Computed:            true,
+		MarkdownDescription: "Digest of the image.",
+	}
+
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Provides information about a set of images of the Unikraft Cloud registry. " +
+			"The platform API does not support enumerating the images of a namespace, " +
+			"so the images are looked up by tag.",
+
+		Attributes: map[string]schema.Attribute{
+			"metro": metroAttribute(),
+			"tags": schema.ListAttribute{
+				Required:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Tags of the images to look up.",
+			},
+			"images": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Images matching `tags`, in the same order.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: attrs,
+				},
+			},
+		},
+	}
+}
+
+// Configure implements datasource.DataSource.
+func (d *ImagesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	pool, ok := req.ProviderData.(*clients.Pool)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *clients.Pool, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.clients = pool
+}
+
+// Read implements datasource.DataSource.
+func (d *ImagesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ImagesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := d.clients.Client(&data.Metro)
+
+	tags := make([]string, 0, len(data.Tags.Elements()))
+	resp.Diagnostics.Append(data.Tags.ElementsAs(ctx, &tags, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Images = make([]models.ImageModel, len(tags))
+	for i, tag := range tags {
+		imgResp, err := client.GetImageByTag(ctx, tag)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Client Error",
+				fmt.Sprintf("Failed to get image %s, got error: %v", tag, err),
+			)
+			return
+		}
+
+		if imgResp == nil || imgResp.Data == nil || imgResp.Data.Image == nil {
+			resp.Diagnostics.AddError(
+				"Client Error",
+				"Empty response from get image API",
+			)
+			return
+		}
+
+		resp.Diagnostics.Append(readImage(ctx, imgResp.Data.Image, &data.Images[i])...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
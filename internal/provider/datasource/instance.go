@@ -6,14 +6,15 @@ package datasource
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/unikraft-cloud/terraform-provider-unikraft-cloud/internal/provider/clients"
 	models "github.com/unikraft-cloud/terraform-provider-unikraft-cloud/internal/provider/model"
-
-	"unikraft.com/cloud/sdk/platform"
 )
 
 func NewInstanceDataSource() datasource.DataSource {
@@ -22,7 +23,7 @@ func NewInstanceDataSource() datasource.DataSource {
 
 // InstanceDataSource defines the data source implementation.
 type InstanceDataSource struct {
-	client platform.Client
+	clients *clients.Pool
 }
 
 // Ensure InstanceDataSource satisfies various datasource interfaces.
@@ -30,6 +31,8 @@ var _ datasource.DataSource = &InstanceDataSource{}
 
 // InstanceDataSourceModel describes the data source data model.
 type InstanceDataSourceModel struct {
+	Metro types.String `tfsdk:"metro"`
+
 	UUID types.String `tfsdk:"uuid"`
 
 	Name              types.String        `tfsdk:"name"`
@@ -40,11 +43,15 @@ type InstanceDataSourceModel struct {
 	CreatedAt         types.String        `tfsdk:"created_at"`
 	Image             types.String        `tfsdk:"image"`
 	MemoryMB          types.Int64         `tfsdk:"memory_mb"`
+	VCPUs             types.Int64         `tfsdk:"vcpus"`
 	Args              types.List          `tfsdk:"args"`
 	Env               types.Map           `tfsdk:"env"`
 	ServiceGroup      *models.SvcGrpModel `tfsdk:"service_group"`
 	NetworkInterfaces types.List          `tfsdk:"network_interfaces"`
 	BootTimeUS        types.Int64         `tfsdk:"boot_time_us"`
+	RestartPolicy     types.String        `tfsdk:"restart_policy"`
+	ExitCode          types.Int64         `tfsdk:"exit_code"`
+	StopReason        types.String        `tfsdk:"stop_reason"`
 }
 
 // Metadata implements datasource.DataSource.
@@ -59,6 +66,7 @@ func (d *InstanceDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 		MarkdownDescription: "Provides state information about a Unikraft Cloud instance.",
 
 		Attributes: map[string]schema.Attribute{
+			"metro": metroAttribute(),
 			"uuid": schema.StringAttribute{
 				Required: true,
 				MarkdownDescription: "Unique identifier of the " +
@@ -88,6 +96,9 @@ func (d *InstanceDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 			"memory_mb": schema.Int64Attribute{
 				Computed: true,
 			},
+			"vcpus": schema.Int64Attribute{
+				Computed: true,
+			},
 			"args": schema.ListAttribute{
 				ElementType: types.StringType,
 				Computed:    true,
@@ -122,6 +133,33 @@ func (d *InstanceDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 							},
 						},
 					},
+					"domains": schema.ListNestedAttribute{
+						Computed: true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"name": schema.StringAttribute{
+									Computed: true,
+								},
+								"fqdn": schema.StringAttribute{
+									Computed: true,
+								},
+								"certificate": schema.SingleNestedAttribute{
+									Computed: true,
+									Attributes: map[string]schema.Attribute{
+										"uuid": schema.StringAttribute{
+											Computed: true,
+										},
+										"name": schema.StringAttribute{
+											Computed: true,
+										},
+										"state": schema.StringAttribute{
+											Computed: true,
+										},
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 			"network_interfaces": schema.ListNestedAttribute{
@@ -146,6 +184,18 @@ func (d *InstanceDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 			"boot_time_us": schema.Int64Attribute{
 				Computed: true,
 			},
+			"restart_policy": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "What the platform does when the application of the instance exits (never, always, on-failure).",
+			},
+			"exit_code": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Exit code of the application the last time the instance stopped.",
+			},
+			"stop_reason": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Who or what stopped the instance the last time it stopped, and why.",
+			},
 		},
 	}
 }
@@ -157,16 +207,16 @@ func (d *InstanceDataSource) Configure(ctx context.Context, req datasource.Confi
 		return
 	}
 
-	client, ok := req.ProviderData.(platform.Client)
+	pool, ok := req.ProviderData.(*clients.Pool)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected platform.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *clients.Pool, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	d.client = client
+	d.clients = pool
 }
 
 // Read implements datasource.DataSource.
@@ -179,7 +229,9 @@ func (d *InstanceDataSource) Read(ctx context.Context, req datasource.ReadReques
 		return
 	}
 
-	insResp, err := d.client.GetInstanceByUUID(ctx, data.UUID.ValueString(), true)
+	client := d.clients.Client(&data.Metro)
+
+	insResp, err := client.GetInstanceByUUID(ctx, data.UUID.ValueString(), true)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Client Error",
@@ -220,9 +272,25 @@ func (d *InstanceDataSource) Read(ctx context.Context, req datasource.ReadReques
 	if ins.MemoryMb != nil {
 		data.MemoryMB = types.Int64Value(int64(*ins.MemoryMb))
 	}
+	if ins.Vcpus != nil {
+		data.VCPUs = types.Int64Value(int64(*ins.Vcpus))
+	}
 	if ins.BootTimeUs != nil {
 		data.BootTimeUS = types.Int64Value(int64(*ins.BootTimeUs))
 	}
+	if ins.RestartPolicy != nil {
+		data.RestartPolicy = types.StringValue(strings.ReplaceAll(string(*ins.RestartPolicy), "_", "-"))
+	}
+	if ins.ExitCode != nil {
+		data.ExitCode = types.Int64Value(int64(*ins.ExitCode))
+	}
+	if ins.StopReason != nil {
+		reason := ins.DescribeStopOrigin()
+		if desc := ins.DescribeStopReason(); desc != "" {
+			reason += ", " + desc
+		}
+		data.StopReason = types.StringValue(reason)
+	}
 
 	if ins.Args != nil {
 		data.Args, diags = types.ListValueFrom(ctx, types.StringType, ins.Args)
@@ -245,6 +313,22 @@ func (d *InstanceDataSource) Read(ctx context.Context, req datasource.ReadReques
 		// Note: InstanceServiceGroup only contains Uuid, Name, and Domains
 		// Services are exposed through the Domains field
 		sgModel.Services = []models.SvcModel{}
+		for _, dom := range ins.ServiceGroup.Domains {
+			crt := types.ObjectNull(models.DomainCertificateModelType.AttrTypes)
+			if dom.Certificate != nil {
+				crt, diags = types.ObjectValue(models.DomainCertificateModelType.AttrTypes, map[string]attr.Value{
+					"uuid":  types.StringPointerValue(dom.Certificate.Uuid),
+					"name":  types.StringPointerValue(dom.Certificate.Name),
+					"state": types.StringNull(),
+				})
+				resp.Diagnostics.Append(diags...)
+			}
+			sgModel.Domains = append(sgModel.Domains, models.DomainModel{
+				Name:        types.StringPointerValue(dom.Fqdn),
+				FQDN:        types.StringPointerValue(dom.Fqdn),
+				Certificate: crt,
+			})
+		}
 		data.ServiceGroup = sgModel
 	} else {
 		data.ServiceGroup = &models.SvcGrpModel{}
@@ -16,6 +16,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
+	"github.com/unikraft-cloud/terraform-provider-unikraft-cloud/internal/provider/clients"
+
 	"unikraft.com/cloud/sdk/platform"
 )
 
@@ -25,7 +27,7 @@ func NewInstancesDataSource() datasource.DataSource {
 
 // InstancesDataSource defines the data source implementation.
 type InstancesDataSource struct {
-	client platform.Client
+	clients *clients.Pool
 }
 
 // Ensure InstancesDataSource satisfies various datasource interfaces.
@@ -33,6 +35,8 @@ var _ datasource.DataSource = &InstancesDataSource{}
 
 // InstancesDataSourceModel describes the data source data model.
 type InstancesDataSourceModel struct {
+	Metro types.String `tfsdk:"metro"`
+
 	States types.Set `tfsdk:"states"`
 
 	UUIDs types.List `tfsdk:"uuids"`
@@ -50,6 +54,7 @@ func (d *InstancesDataSource) Schema(ctx context.Context, req datasource.SchemaR
 		MarkdownDescription: "Provides UUIDs of existing Unikraft Cloud instances.",
 
 		Attributes: map[string]schema.Attribute{
+			"metro": metroAttribute(),
 			"states": schema.SetAttribute{
 				ElementType: types.StringType,
 				MarkdownDescription: "Filter instances based on their current " +
@@ -82,16 +87,16 @@ func (d *InstancesDataSource) Configure(ctx context.Context, req datasource.Conf
 		return
 	}
 
-	client, ok := req.ProviderData.(platform.Client)
+	pool, ok := req.ProviderData.(*clients.Pool)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected platform.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *clients.Pool, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	d.client = client
+	d.clients = pool
 }
 
 // Read implements datasource.DataSource.
@@ -104,7 +109,9 @@ func (d *InstancesDataSource) Read(ctx context.Context, req datasource.ReadReque
 		return
 	}
 
-	insResp, err := d.client.GetInstances(ctx, nil, false)
+	client := d.clients.Client(&data.Metro)
+
+	insResp, err := client.GetInstances(ctx, nil, false)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Client Error",
@@ -138,7 +145,7 @@ func (d *InstancesDataSource) Read(ctx context.Context, req datasource.ReadReque
 			if ins.Uuid == nil {
 				continue
 			}
-			insFullResp, err := d.client.GetInstanceByUUID(ctx, *ins.Uuid, false)
+			insFullResp, err := client.GetInstanceByUUID(ctx, *ins.Uuid, false)
 			if err != nil {
 				resp.Diagnostics.AddError(
 					"Client Error",
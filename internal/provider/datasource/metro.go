@@ -0,0 +1,18 @@
+// Copyright (c) Unikraft GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package datasource
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+)
+
+// metroAttribute returns the schema of the metro a data source reads from. It
+// is shared by all data sources.
+func metroAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:            true,
+		Computed:            true,
+		MarkdownDescription: "Metro to read from. Defaults to the metro of the provider.",
+	}
+}
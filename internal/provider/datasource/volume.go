@@ -11,9 +11,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/unikraft-cloud/terraform-provider-unikraft-cloud/internal/provider/clients"
 	models "github.com/unikraft-cloud/terraform-provider-unikraft-cloud/internal/provider/model"
-
-	"unikraft.com/cloud/sdk/platform"
 )
 
 func NewVolumeDataSource() datasource.DataSource {
@@ -22,7 +21,7 @@ func NewVolumeDataSource() datasource.DataSource {
 
 // VolumeDataSource defines the data source implementation.
 type VolumeDataSource struct {
-	client platform.Client
+	clients *clients.Pool
 }
 
 // Ensure VolumeDataSource satisfies various datasource interfaces.
@@ -30,6 +29,8 @@ var _ datasource.DataSource = &VolumeDataSource{}
 
 // VolumeDataSourceModel describes the data source data model.
 type VolumeDataSourceModel struct {
+	Metro types.String `tfsdk:"metro"`
+
 	UUID types.String `tfsdk:"uuid"`
 
 	Name       types.String `tfsdk:"name"`
@@ -53,6 +54,7 @@ func (d *VolumeDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 		MarkdownDescription: "Provides state information about a Unikraft Cloud volume.",
 
 		Attributes: map[string]schema.Attribute{
+			"metro": metroAttribute(),
 			"uuid": schema.StringAttribute{
 				Required: true,
 				MarkdownDescription: "Unique identifier of the " +
@@ -125,16 +127,16 @@ func (d *VolumeDataSource) Configure(ctx context.Context, req datasource.Configu
 		return
 	}
 
-	client, ok := req.ProviderData.(platform.Client)
+	pool, ok := req.ProviderData.(*clients.Pool)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected platform.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *clients.Pool, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	d.client = client
+	d.clients = pool
 }
 
 // Read implements datasource.DataSource.
@@ -147,7 +149,9 @@ func (d *VolumeDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	volResp, err := d.client.GetVolumeByUUID(ctx, data.UUID.ValueString(), true)
+	client := d.clients.Client(&data.Metro)
+
+	volResp, err := client.GetVolumeByUUID(ctx, data.UUID.ValueString(), true)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Client Error",
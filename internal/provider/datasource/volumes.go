@@ -16,6 +16,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
+	"github.com/unikraft-cloud/terraform-provider-unikraft-cloud/internal/provider/clients"
+
 	"unikraft.com/cloud/sdk/platform"
 )
 
@@ -25,7 +27,7 @@ func NewVolumesDataSource() datasource.DataSource {
 
 // VolumesDataSource defines the data source implementation.
 type VolumesDataSource struct {
-	client platform.Client
+	clients *clients.Pool
 }
 
 // Ensure VolumesDataSource satisfies various datasource interfaces.
@@ -33,6 +35,8 @@ var _ datasource.DataSource = &VolumesDataSource{}
 
 // VolumesDataSourceModel describes the data source data model.
 type VolumesDataSourceModel struct {
+	Metro types.String `tfsdk:"metro"`
+
 	States types.Set `tfsdk:"states"`
 
 	UUIDs types.List `tfsdk:"uuids"`
@@ -50,6 +54,7 @@ func (d *VolumesDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 		MarkdownDescription: "Provides UUIDs of existing Unikraft Cloud volumes.",
 
 		Attributes: map[string]schema.Attribute{
+			"metro": metroAttribute(),
 			"states": schema.SetAttribute{
 				ElementType:         types.StringType,
 				MarkdownDescription: "Filter volumes based on their current state",
@@ -84,16 +89,16 @@ func (d *VolumesDataSource) Configure(ctx context.Context, req datasource.Config
 		return
 	}
 
-	client, ok := req.ProviderData.(platform.Client)
+	pool, ok := req.ProviderData.(*clients.Pool)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected platform.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *clients.Pool, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	d.client = client
+	d.clients = pool
 }
 
 // Read implements datasource.DataSource.
@@ -106,7 +111,9 @@ func (d *VolumesDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		return
 	}
 
-	volResp, err := d.client.GetVolumes(ctx, nil, false)
+	client := d.clients.Client(&data.Metro)
+
+	volResp, err := client.GetVolumes(ctx, nil, false)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Client Error",
@@ -139,7 +146,7 @@ func (d *VolumesDataSource) Read(ctx context.Context, req datasource.ReadRequest
 			if vol.Uuid == nil {
 				continue
 			}
-			volFullResp, err := d.client.GetVolumeByUUID(ctx, *vol.Uuid, false)
+			volFullResp, err := client.GetVolumeByUUID(ctx, *vol.Uuid, false)
 			if err != nil {
 				resp.Diagnostics.AddError(
 					"Client Error",
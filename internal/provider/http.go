@@ -0,0 +1,92 @@
+// Copyright (c) Unikraft GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+)
+
+// httpClientConfig holds the settings of the HTTP client the API clients send
+// their requests with.
+type httpClientConfig struct {
+	// CACertFile is the path to a PEM file of certificate authorities which
+	// are trusted in addition to the ones of the system.
+	CACertFile string
+	// InsecureSkipVerify disables the verification of the certificate of the
+	// API.
+	InsecureSkipVerify bool
+	// ProxyURL is the URL of the proxy requests are sent through. Proxies set
+	// in the environment are used when it is nil.
+	ProxyURL *url.URL
+	// MaxRetries is the number of times requests failing with transient
+	// errors are retried.
+	MaxRetries int
+	// MaxRequestsPerSecond limits the rate of requests sent to the API. Zero
+	// disables the limit.
+	MaxRequestsPerSecond float64
+}
+
+// newHTTPClient returns an HTTP client configured according to cfg.
+func newHTTPClient(cfg httpClientConfig) (*http.Client, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: cfg.InsecureSkipVerify, //nolint:gosec // explicitly requested by the user
+	}
+
+	if cfg.CACertFile != "" {
+		pem, err := os.ReadFile(cfg.CACertFile)
+		if err != nil {
+			return nil, err
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificate found in %s", cfg.CACertFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	proxy := http.ProxyFromEnvironment
+	if cfg.ProxyURL != nil {
+		proxy = http.ProxyURL(cfg.ProxyURL)
+	}
+
+	transport := &http.Transport{
+		// Keep-alives are disabled like in the client of the SDK, due to
+		// issues with the proxy in front of the API.
+		DisableKeepAlives: true,
+		Proxy:             proxy,
+		TLSClientConfig:   tlsConfig,
+	}
+
+	return &http.Client{
+		// Every attempt of a retried request is logged.
+		Transport: newRetryTransport(&loggingTransport{next: transport}, cfg.MaxRetries, cfg.MaxRequestsPerSecond),
+	}, nil
+}
+
+// parseURL parses an absolute URL with one of the given schemes.
+func parseURL(s string, schemes ...string) (*url.URL, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(schemes, u.Scheme) {
+		return nil, fmt.Errorf("the URL must have one of the schemes %s", strings.Join(schemes, ", "))
+	}
+	if u.Host == "" {
+		return nil, errors.New("the URL must have a host")
+	}
+	return u, nil
+}
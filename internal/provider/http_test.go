@@ -0,0 +1,97 @@
+// Copyright (c) Unikraft GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewHTTPClient(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	// The certificate of the server is not trusted by default.
+	c, err := newHTTPClient(httpClientConfig{})
+	assert.NoError(t, err)
+	_, err = c.Get(srv.URL)
+	assert.Error(t, err)
+
+	c, err = newHTTPClient(httpClientConfig{InsecureSkipVerify: true})
+	assert.NoError(t, err)
+	res, err := c.Get(srv.URL)
+	if assert.NoError(t, err) {
+		res.Body.Close()
+		assert.Equal(t, http.StatusNoContent, res.StatusCode)
+	}
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	err = os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: srv.Certificate().Raw,
+	}), 0o600)
+	assert.NoError(t, err)
+
+	c, err = newHTTPClient(httpClientConfig{CACertFile: caFile})
+	assert.NoError(t, err)
+	res, err = c.Get(srv.URL)
+	if assert.NoError(t, err) {
+		res.Body.Close()
+		assert.Equal(t, http.StatusNoContent, res.StatusCode)
+	}
+}
+
+func TestNewHTTPClient_InvalidCACertFile(t *testing.T) {
+	_, err := newHTTPClient(httpClientConfig{CACertFile: filepath.Join(t.TempDir(), "missing.pem")})
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	assert.NoError(t, os.WriteFile(caFile, []byte("not a certificate"), 0o600))
+	_, err = newHTTPClient(httpClientConfig{CACertFile: caFile})
+	assert.ErrorContains(t, err, "no certificate found")
+}
+
+func TestNewHTTPClient_Proxy(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer proxy.Close()
+
+	u, err := url.Parse(proxy.URL)
+	assert.NoError(t, err)
+
+	c, err := newHTTPClient(httpClientConfig{ProxyURL: u})
+	assert.NoError(t, err)
+	res, err := c.Get("http://api.fra0.example.com/v1/instances")
+	if assert.NoError(t, err) {
+		res.Body.Close()
+	}
+	assert.Equal(t, "http://api.fra0.example.com/v1/instances", proxied)
+}
+
+func TestParseURL(t *testing.T) {
+	u, err := parseURL("https://api.example.com/fra0", "http", "https")
+	assert.NoError(t, err)
+	assert.Equal(t, "api.example.com", u.Host)
+
+	_, err = parseURL("socks5://proxy:1080", "http", "https")
+	assert.Error(t, err)
+
+	_, err = parseURL("https:///fra0", "http", "https")
+	assert.Error(t, err)
+
+	_, err = parseURL("api.example.com", "http", "https")
+	assert.Error(t, err)
+}
@@ -0,0 +1,210 @@
+// Copyright (c) Unikraft GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"maps"
+	"mime"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// apiLogSubsystem is the tflog subsystem API requests and responses are
+// logged to. Its level can be set independently of the provider with the
+// TF_LOG_PROVIDER_UKC_API environment variable.
+const apiLogSubsystem = "api"
+
+// apiLogLevelEnvs are the environment variables the level of the API subsystem
+// is set from, the most specific first: the subsystem, the provider, every
+// provider and Terraform as a whole.
+var apiLogLevelEnvs = []string{"TF_LOG_PROVIDER_UKC_API", "TF_LOG_PROVIDER_UKC", "TF_LOG_PROVIDER", "TF_LOG"}
+
+// redacted replaces the values of secrets in logs.
+const redacted = "<redacted>"
+
+// requestIDHeaders are the response headers the ID of a request is read from,
+// in order of preference.
+var requestIDHeaders = []string{"X-Request-Id", "X-Correlation-Id", "Cf-Ray"}
+
+// sensitiveBodyKeys are the keys of request and response bodies whose values
+// are redacted in logs.
+var sensitiveBodyKeys = map[string]bool{
+	"pkey":  true,
+	"token": true,
+}
+
+// loggingTransport is an http.RoundTripper logging every request sent to the
+// API and its response. The method, path, status, latency and request ID are
+// logged at DEBUG level, the headers and bodies at TRACE level, with the API
+// token and private keys of certificates redacted. Bodies are only buffered
+// when TRACE logs are enabled for the API subsystem.
+type loggingTransport struct {
+	next http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := newAPILogContext(req.Context(), req)
+
+	fields := map[string]any{
+		"method": req.Method,
+		"host":   req.URL.Host,
+		"path":   req.URL.Path,
+	}
+	if req.URL.RawQuery != "" {
+		fields["query"] = req.URL.RawQuery
+	}
+
+	trace := traceEnabled()
+	if trace {
+		f := merge(fields, map[string]any{
+			"headers": redactHeaders(req.Header),
+		})
+		if req.Body != nil && req.GetBody != nil {
+			if body, err := req.GetBody(); err == nil {
+				b, _ := io.ReadAll(body)
+				body.Close()
+				f["body"] = redactBody(b)
+			}
+		}
+		tflog.SubsystemTrace(ctx, apiLogSubsystem, "Sending Unikraft Cloud API request", f)
+	}
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	fields["latency_ms"] = time.Since(start).Milliseconds()
+
+	if err != nil {
+		tflog.SubsystemDebug(ctx, apiLogSubsystem, "Unikraft Cloud API request failed", merge(fields, map[string]any{
+			"error": err.Error(),
+		}))
+		return resp, err
+	}
+
+	fields["status"] = resp.StatusCode
+	for _, h := range requestIDHeaders {
+		if id := resp.Header.Get(h); id != "" {
+			fields["request_id"] = id
+			break
+		}
+	}
+
+	tflog.SubsystemDebug(ctx, apiLogSubsystem, "Received Unikraft Cloud API response", fields)
+
+	if !trace {
+		return resp, nil
+	}
+
+	// Event streams are consumed while Terraform runs and cannot be buffered.
+	if ct, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); ct != "text/event-stream" {
+		b, rerr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(b))
+		if rerr != nil {
+			return nil, rerr
+		}
+
+		tflog.SubsystemTrace(ctx, apiLogSubsystem, "Received Unikraft Cloud API response body", merge(fields, map[string]any{
+			"headers": redactHeaders(resp.Header),
+			"body":    redactBody(b),
+		}))
+	}
+
+	return resp, nil
+}
+
+// traceEnabled reports whether TRACE logs are enabled for the API subsystem.
+// tflog does not expose the level of its loggers, so it is derived from the
+// environment variables Terraform and tflog read it from.
+func traceEnabled() bool {
+	for _, env := range apiLogLevelEnvs {
+		switch strings.ToUpper(os.Getenv(env)) {
+		case "":
+			continue
+		case "TRACE", "JSON":
+			return true
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+// newAPILogContext returns a context logging to the API subsystem, which masks
+// the API token req is authenticated with wherever it appears.
+func newAPILogContext(ctx context.Context, req *http.Request) context.Context {
+	ctx = tflog.NewSubsystem(ctx, apiLogSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_UKC_API"))
+
+	auth := req.Header.Get("Authorization")
+	if token := strings.TrimSpace(strings.TrimPrefix(auth, "Bearer ")); token != "" {
+		ctx = tflog.SubsystemMaskAllFieldValuesStrings(ctx, apiLogSubsystem, token)
+		ctx = tflog.SubsystemMaskMessageStrings(ctx, apiLogSubsystem, token)
+	}
+	return ctx
+}
+
+// redactHeaders returns the headers h with the values of the credentials
+// replaced.
+func redactHeaders(h http.Header) map[string]string {
+	out := make(map[string]string, len(h))
+	for k := range h {
+		switch http.CanonicalHeaderKey(k) {
+		case "Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie":
+			out[k] = redacted
+		default:
+			out[k] = h.Get(k)
+		}
+	}
+	return out
+}
+
+// redactBody returns the JSON body b with the values of sensitiveBodyKeys
+// replaced at any depth. Bodies which are not JSON are returned as is.
+func redactBody(b []byte) string {
+	var v any
+	if err := json.Unmarshal(b, &v); err != nil {
+		return string(b)
+	}
+
+	out, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return string(b)
+	}
+	return string(out)
+}
+
+// redactValue replaces the values of sensitiveBodyKeys in the decoded JSON
+// value v.
+func redactValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, e := range v {
+			if sensitiveBodyKeys[k] {
+				v[k] = redacted
+				continue
+			}
+			v[k] = redactValue(e)
+		}
+	case []any:
+		for i, e := range v {
+			v[i] = redactValue(e)
+		}
+	}
+	return v
+}
+
+// merge returns a copy of fields with the entries of extra added.
+func merge(fields, extra map[string]any) map[string]any {
+	out := maps.Clone(fields)
+	maps.Copy(out, extra)
+	return out
+}
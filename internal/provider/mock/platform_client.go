@@ -16,6 +16,30 @@ type PlatformClient struct {
 	mock.Mock
 }
 
+// Client returns a platform.Client backed by the mock. Calling a method which
+// is not mocked panics.
+func (m *PlatformClient) Client() platform.Client {
+	return client{PlatformClient: m}
+}
+
+// client adds the methods of platform.Client which are not mocked to a
+// PlatformClient. They are promoted from a nil interface one level deeper, so
+// that the mocked methods take precedence.
+type client struct {
+	*PlatformClient
+	unmocked
+}
+
+// unmocked provides the methods of platform.Client which are not mocked.
+type unmocked struct {
+	platform.Client
+}
+
+// WithMetro returns the client itself, so that it can back a client pool.
+func (c client) WithMetro(metro string) platform.Client {
+	return c
+}
+
 // Instance methods
 
 func (m *PlatformClient) CreateInstance(ctx context.Context, req platform.CreateInstanceRequest, ropts ...platform.RequestOption) (*platform.Response[platform.CreateInstanceResponseData], error) {
@@ -50,6 +74,72 @@ func (m *PlatformClient) DeleteInstanceByUUID(ctx context.Context, uuid string,
 	return args.Get(0).(*platform.Response[platform.DeleteInstancesResponseData]), args.Error(1)
 }
 
+func (m *PlatformClient) StartInstanceByUUID(ctx context.Context, uuid string, ropts ...platform.RequestOption) (*platform.Response[platform.StartInstancesResponseData], error) {
+	args := m.Called(ctx, uuid)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*platform.Response[platform.StartInstancesResponseData]), args.Error(1)
+}
+
+func (m *PlatformClient) StopInstanceByUUID(ctx context.Context, uuid string, force bool, drainTimeoutMs int32, ropts ...platform.RequestOption) (*platform.Response[platform.StopInstancesResponseData], error) {
+	args := m.Called(ctx, uuid, force, drainTimeoutMs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*platform.Response[platform.StopInstancesResponseData]), args.Error(1)
+}
+
+func (m *PlatformClient) UpdateInstanceByUUID(ctx context.Context, uuid string, request platform.UpdateInstanceByUUIDRequestBody, ropts ...platform.RequestOption) (*platform.Response[platform.UpdateInstancesResponseData], error) {
+	args := m.Called(ctx, uuid, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*platform.Response[platform.UpdateInstancesResponseData]), args.Error(1)
+}
+
+func (m *PlatformClient) WaitInstanceByUUID(ctx context.Context, uuid string, request platform.WaitInstanceByUUIDRequestBody, ropts ...platform.RequestOption) (*platform.Response[platform.WaitInstancesResponseData], error) {
+	args := m.Called(ctx, uuid, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*platform.Response[platform.WaitInstancesResponseData]), args.Error(1)
+}
+
+// Service group methods
+
+func (m *PlatformClient) CreateServiceGroup(ctx context.Context, req platform.CreateServiceGroupRequest, ropts ...platform.RequestOption) (*platform.Response[platform.CreateServiceGroupResponseData], error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*platform.Response[platform.CreateServiceGroupResponseData]), args.Error(1)
+}
+
+func (m *PlatformClient) GetServiceGroupByUUID(ctx context.Context, uuid string, details bool, ropts ...platform.RequestOption) (*platform.Response[platform.GetServiceGroupsResponseData], error) {
+	args := m.Called(ctx, uuid, details)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*platform.Response[platform.GetServiceGroupsResponseData]), args.Error(1)
+}
+
+func (m *PlatformClient) UpdateServiceGroupByUUID(ctx context.Context, uuid string, request platform.UpdateServiceGroupByUUIDRequestBody, ropts ...platform.RequestOption) (*platform.Response[platform.UpdateServiceGroupsResponseData], error) {
+	args := m.Called(ctx, uuid, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*platform.Response[platform.UpdateServiceGroupsResponseData]), args.Error(1)
+}
+
+func (m *PlatformClient) DeleteServiceGroupByUUID(ctx context.Context, uuid string, ropts ...platform.RequestOption) (*platform.Response[platform.DeleteServiceGroupsResponseData], error) {
+	args := m.Called(ctx, uuid)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*platform.Response[platform.DeleteServiceGroupsResponseData]), args.Error(1)
+}
+
 // Certificate methods
 
 func (m *PlatformClient) CreateCertificate(ctx context.Context, req platform.CreateCertificateRequest, ropts ...platform.RequestOption) (*platform.Response[platform.CreateCertificateResponseData], error) {
@@ -109,3 +199,83 @@ func (m *PlatformClient) UpdateVolumeByUUID(ctx context.Context, uuid string, re
 	}
 	return args.Get(0).(*platform.Response[platform.UpdateVolumesResponseData]), args.Error(1)
 }
+
+func (m *PlatformClient) AttachVolumeByUUID(ctx context.Context, uuid string, request platform.AttachVolumeByUUIDRequestBody, ropts ...platform.RequestOption) (*platform.Response[platform.AttachVolumesResponseData], error) {
+	args := m.Called(ctx, uuid, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*platform.Response[platform.AttachVolumesResponseData]), args.Error(1)
+}
+
+func (m *PlatformClient) DetachVolumeByUUID(ctx context.Context, uuid string, request platform.DetachVolumeByUUIDRequestBody, ropts ...platform.RequestOption) (*platform.Response[platform.DetachVolumesResponseData], error) {
+	args := m.Called(ctx, uuid, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*platform.Response[platform.DetachVolumesResponseData]), args.Error(1)
+}
+
+func (m *PlatformClient) CreateAutoscaleConfigurationByServiceGroupUUID(ctx context.Context, uuid string, request platform.CreateAutoscaleConfigurationByServiceGroupUUIDRequest, ropts ...platform.RequestOption) (*platform.Response[platform.CreateAutoscaleConfigurationsResponseData], error) {
+	args := m.Called(ctx, uuid, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*platform.Response[platform.CreateAutoscaleConfigurationsResponseData]), args.Error(1)
+}
+
+func (m *PlatformClient) GetAutoscaleConfigurationsByServiceGroupUUID(ctx context.Context, uuid string, ropts ...platform.RequestOption) (*platform.Response[platform.GetAutoscaleConfigurationsResponseData], error) {
+	args := m.Called(ctx, uuid)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*platform.Response[platform.GetAutoscaleConfigurationsResponseData]), args.Error(1)
+}
+
+func (m *PlatformClient) DeleteAutoscaleConfigurationsByServiceGroupUUID(ctx context.Context, uuid string, ropts ...platform.RequestOption) (*platform.Response[platform.DeleteAutoscaleConfigurationsResponseData], error) {
+	args := m.Called(ctx, uuid)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*platform.Response[platform.DeleteAutoscaleConfigurationsResponseData]), args.Error(1)
+}
+
+func (m *PlatformClient) CreateAutoscaleConfigurationPolicy(ctx context.Context, uuid string, request platform.CreateAutoscaleConfigurationPolicyRequest, ropts ...platform.RequestOption) (*platform.Response[platform.CreateAutoscaleConfigurationPolicyResponseData], error) {
+	args := m.Called(ctx, uuid, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*platform.Response[platform.CreateAutoscaleConfigurationPolicyResponseData]), args.Error(1)
+}
+
+func (m *PlatformClient) DeleteAutoscaleConfigurationPolicyByName(ctx context.Context, uuid string, name string, ropts ...platform.RequestOption) (*platform.Response[platform.DeleteAutoscaleConfigurationPolicyResponseData], error) {
+	args := m.Called(ctx, uuid, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*platform.Response[platform.DeleteAutoscaleConfigurationPolicyResponseData]), args.Error(1)
+}
+
+func (m *PlatformClient) GetUser(ctx context.Context, ropts ...platform.RequestOption) (*platform.Response[platform.QuotasResponseData], error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*platform.Response[platform.QuotasResponseData]), args.Error(1)
+}
+
+func (m *PlatformClient) GetImageByDigest(ctx context.Context, digest string, ropts ...platform.RequestOption) (*platform.Response[platform.GetImageResponseData], error) {
+	args := m.Called(ctx, digest)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*platform.Response[platform.GetImageResponseData]), args.Error(1)
+}
+
+func (m *PlatformClient) GetImageByTag(ctx context.Context, tag string, ropts ...platform.RequestOption) (*platform.Response[platform.GetImageResponseData], error) {
+	args := m.Called(ctx, tag)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*platform.Response[platform.GetImageResponseData]), args.Error(1)
+}
@@ -13,7 +13,7 @@ type SvcGrpModel struct {
 	UUID     types.String  `tfsdk:"uuid"`
 	Name     types.String  `tfsdk:"name"`
 	Services []SvcModel    `tfsdk:"services"`
-	Domains  []domainModel `tfsdk:"domains"`
+	Domains  []DomainModel `tfsdk:"domains"`
 }
 
 // svcModel describes the data model for a service group's service.
@@ -40,18 +40,29 @@ var NetwIfaceModelType = types.ObjectType{
 	},
 }
 
-type domainModel struct {
-	Name        types.String      `tfsdk:"name"`
-	FQDN        types.String      `tfsdk:"fqdn"`
-	Certificate *certificateModel `tfsdk:"certificate"`
+// DomainModel describes the data model for a service group's domain.
+type DomainModel struct {
+	Name        types.String `tfsdk:"name"`
+	FQDN        types.String `tfsdk:"fqdn"`
+	Certificate types.Object `tfsdk:"certificate"`
 }
 
-type certificateModel struct {
+// DomainCertificateModel describes the data model for the certificate of a
+// service group's domain.
+type DomainCertificateModel struct {
 	UUID  types.String `tfsdk:"uuid"`
 	Name  types.String `tfsdk:"name"`
 	State types.String `tfsdk:"state"`
 }
 
+var DomainCertificateModelType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"uuid":  types.StringType,
+		"name":  types.StringType,
+		"state": types.StringType,
+	},
+}
+
 // VolumeInstanceModel describes the data model for an instance attached to a volume.
 type VolumeInstanceModel struct {
 	UUID types.String `tfsdk:"uuid"`
@@ -79,3 +90,71 @@ var VolumeMountModelType = types.ObjectType{
 		"read_only": types.BoolType,
 	},
 }
+
+// InstanceVolumeModel describes the data model for a volume mounted by an instance.
+type InstanceVolumeModel struct {
+	UUID     types.String `tfsdk:"uuid"`
+	Name     types.String `tfsdk:"name"`
+	At       types.String `tfsdk:"at"`
+	ReadOnly types.Bool   `tfsdk:"read_only"`
+}
+
+var InstanceVolumeModelType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"uuid":      types.StringType,
+		"name":      types.StringType,
+		"at":        types.StringType,
+		"read_only": types.BoolType,
+	},
+}
+
+// ScaleToZeroModel describes the data model for the scale-to-zero configuration of an instance.
+type ScaleToZeroModel struct {
+	Policy         types.String `tfsdk:"policy"`
+	Stateful       types.Bool   `tfsdk:"stateful"`
+	CooldownTimeMs types.Int64  `tfsdk:"cooldown_time_ms"`
+}
+
+// AutoscaleConfigurationModel describes the data model for the autoscale configuration of a service group.
+type AutoscaleConfigurationModel struct {
+	ServiceGroupUUID     types.String           `tfsdk:"service_group_uuid"`
+	MinSize              types.Int64            `tfsdk:"min_size"`
+	MaxSize              types.Int64            `tfsdk:"max_size"`
+	WarmupTimeMs         types.Int64            `tfsdk:"warmup_time_ms"`
+	CooldownTimeMs       types.Int64            `tfsdk:"cooldown_time_ms"`
+	TemplateInstanceUUID types.String           `tfsdk:"template_instance_uuid"`
+	Policies             []AutoscalePolicyModel `tfsdk:"policies"`
+}
+
+// AutoscalePolicyModel describes the data model for a scaling policy of an autoscale configuration.
+type AutoscalePolicyModel struct {
+	Name           types.String         `tfsdk:"name"`
+	Enabled        types.Bool           `tfsdk:"enabled"`
+	Metric         types.String         `tfsdk:"metric"`
+	AdjustmentType types.String         `tfsdk:"adjustment_type"`
+	Steps          []AutoscaleStepModel `tfsdk:"steps"`
+}
+
+// AutoscaleStepModel describes the data model for a step of a scaling policy.
+type AutoscaleStepModel struct {
+	Adjustment types.Int64 `tfsdk:"adjustment"`
+	LowerBound types.Int64 `tfsdk:"lower_bound"`
+	UpperBound types.Int64 `tfsdk:"upper_bound"`
+}
+
+// ImageModel describes the data model for an image in the registry.
+type ImageModel struct {
+	Tag          types.String `tfsdk:"tag"`
+	Digest       types.String `tfsdk:"digest"`
+	Description  types.String `tfsdk:"description"`
+	CreatedAt    types.String `tfsdk:"created_at"`
+	Arch         types.String `tfsdk:"arch"`
+	Entrypoint   types.List   `tfsdk:"entrypoint"`
+	Args         types.List   `tfsdk:"args"`
+	Env          types.Map    `tfsdk:"env"`
+	Ports        types.List   `tfsdk:"ports"`
+	Labels       types.Map    `tfsdk:"labels"`
+	Workdir      types.String `tfsdk:"workdir"`
+	KernelDigest types.String `tfsdk:"kernel_digest"`
+	SizeBytes    types.Int64  `tfsdk:"size_bytes"`
+}
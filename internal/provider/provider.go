@@ -5,15 +5,23 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io/fs"
 	"os"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 
+	"github.com/unikraft-cloud/terraform-provider-unikraft-cloud/internal/provider/clients"
 	idatasource "github.com/unikraft-cloud/terraform-provider-unikraft-cloud/internal/provider/datasource"
 	iresource "github.com/unikraft-cloud/terraform-provider-unikraft-cloud/internal/provider/resource"
 
@@ -41,8 +49,22 @@ var _ provider.Provider = &UnikraftCloudProvider{}
 
 // UnikraftCloudModel describes the provider data model.
 type UnikraftCloudModel struct {
-	Metro types.String `tfsdk:"metro"`
-	Token types.String `tfsdk:"token"`
+	Metro      types.String `tfsdk:"metro"`
+	Token      types.String `tfsdk:"token"`
+	ConfigFile types.String `tfsdk:"config_file"`
+	Profile    types.String `tfsdk:"profile"`
+
+	Endpoint           types.String `tfsdk:"endpoint"`
+	CACertFile         types.String `tfsdk:"ca_cert_file"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+	ProxyURL           types.String `tfsdk:"proxy_url"`
+
+	MaxRetries           types.Int64 `tfsdk:"max_retries"`
+	MaxRequestsPerSecond types.Int64 `tfsdk:"max_requests_per_second"`
+
+	UserAgentSuffix types.String `tfsdk:"user_agent_suffix"`
+
+	SkipCredentialsValidation types.Bool `tfsdk:"skip_credentials_validation"`
 }
 
 // Metadata implements provider.Provider.
@@ -59,7 +81,7 @@ func (p *UnikraftCloudProvider) Schema(ctx context.Context, req provider.SchemaR
 
 		Attributes: map[string]schema.Attribute{
 			"metro": schema.StringAttribute{
-				MarkdownDescription: "API metro",
+				MarkdownDescription: "Default API metro. Can be overridden by the `metro` attribute of resources and data sources.",
 				Optional:            true,
 			},
 			"token": schema.StringAttribute{
@@ -67,6 +89,70 @@ func (p *UnikraftCloudProvider) Schema(ctx context.Context, req provider.SchemaR
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"config_file": schema.StringAttribute{
+				MarkdownDescription: "Path to the kraft CLI configuration file to read the token and metro from, " +
+					"when they are set neither in the provider configuration nor in the environment. " +
+					"Can also be set with the `UKC_CONFIG_FILE` environment variable. " +
+					"Defaults to `kraftkit/config.yaml` in the configuration directory of the user.",
+				Optional: true,
+			},
+			"profile": schema.StringAttribute{
+				MarkdownDescription: "Entry of the `auth` section of the kraft CLI configuration file to read the token and metro from. " +
+					"Can also be set with the `UKC_PROFILE` environment variable. " +
+					"Defaults to `" + defaultCLIProfile + "`, which is where `kraft cloud login` stores credentials.",
+				Optional: true,
+			},
+			"endpoint": schema.StringAttribute{
+				MarkdownDescription: "Base URL of the API, e.g. of an on-premises installation or of a local stand-in during testing. " +
+					"A `{metro}` placeholder in the URL is replaced by the metro of each resource and data source; " +
+					"without it, requests for all metros are sent to the same URL. " +
+					"Can also be set with the `UKC_ENDPOINT` environment variable. " +
+					"Defaults to `https://api.{metro}.kraft.cloud`.",
+				Optional: true,
+			},
+			"ca_cert_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a PEM file of certificate authorities to trust in addition to the ones of the system " +
+					"when connecting to the API. Can also be set with the `UKC_CA_CERT_FILE` environment variable.",
+				Optional: true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: "Whether to skip the verification of the TLS certificate of the API. " +
+					"Only use this for testing. Defaults to `false`.",
+				Optional: true,
+			},
+			"proxy_url": schema.StringAttribute{
+				MarkdownDescription: "URL of the HTTP proxy to send API requests through. " +
+					"Defaults to the proxy set by the `HTTPS_PROXY`, `HTTP_PROXY` and `NO_PROXY` environment variables.",
+				Optional: true,
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "Number of times API requests failing with a transient error are retried. " +
+					"Requests rejected with `429 Too Many Requests` are always retried, honouring the `Retry-After` header; " +
+					"network errors and `502`, `503` and `504` responses are only retried for idempotent requests. " +
+					fmt.Sprintf("Set to `0` to disable retries. Defaults to `%d`.", defaultMaxRetries),
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"max_requests_per_second": schema.Int64Attribute{
+				MarkdownDescription: "Maximum rate of requests the provider sends to the API, shared by all resources and data sources. " +
+					fmt.Sprintf("Set to `0` to disable the limit. Defaults to `%d`.", defaultMaxRequestsPerSecond),
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"user_agent_suffix": schema.StringAttribute{
+				MarkdownDescription: "Text appended to the `User-Agent` header of API requests, " +
+					"e.g. to identify the pipeline changes are applied from in the audit logs of the platform.",
+				Optional: true,
+			},
+			"skip_credentials_validation": schema.BoolAttribute{
+				MarkdownDescription: "Whether to skip checking the token and metro against the API when the provider is configured. " +
+					"Useful to plan without access to the API. Defaults to `false`.",
+				Optional: true,
+			},
 		},
 	}
 }
@@ -81,6 +167,18 @@ func (p *UnikraftCloudProvider) Configure(ctx context.Context, req provider.Conf
 		return
 	}
 
+	// Values depending on other resources, e.g. a token read from a secrets
+	// manager, are unknown until those are applied. Let Terraform defer the
+	// resources of this provider to a later round instead of failing, if it
+	// supports doing so.
+	if !req.Config.Raw.IsFullyKnown() && req.ClientCapabilities.DeferralAllowed {
+		tflog.Info(ctx, "Deferring Unikraft Cloud resources until the provider configuration is known")
+		resp.Deferred = &provider.Deferred{
+			Reason: provider.DeferredReasonProviderConfigUnknown,
+		}
+		return
+	}
+
 	// If a configuration value was provided for any of the attributes, it must
 	// be a known value (either literal, or already resolved by Terraform).
 
@@ -102,6 +200,96 @@ func (p *UnikraftCloudProvider) Configure(ctx context.Context, req provider.Conf
 		)
 	}
 
+	if data.ConfigFile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("config_file"),
+			"Unknown kraft CLI Configuration File",
+			"The provider cannot read the kraft CLI configuration file as there is an unknown configuration value for its path. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the UKC_CONFIG_FILE environment variable.",
+		)
+	}
+
+	if data.Endpoint.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("endpoint"),
+			"Unknown Unikraft Cloud API Endpoint",
+			"The provider cannot create the Unikraft Cloud API client as there is an unknown configuration value for the Unikraft Cloud API endpoint. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the UKC_ENDPOINT environment variable.",
+		)
+	}
+
+	if data.CACertFile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ca_cert_file"),
+			"Unknown CA Certificate File",
+			"The provider cannot create the Unikraft Cloud API client as there is an unknown configuration value for the CA certificate file. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the UKC_CA_CERT_FILE environment variable.",
+		)
+	}
+
+	if data.InsecureSkipVerify.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("insecure_skip_verify"),
+			"Unknown TLS Verification Setting",
+			"The provider cannot create the Unikraft Cloud API client as there is an unknown configuration value for insecure_skip_verify. "+
+				"Either target apply the source of the value first, or set the value statically in the configuration.",
+		)
+	}
+
+	if data.MaxRetries.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_retries"),
+			"Unknown Maximum Number of Retries",
+			"The provider cannot create the Unikraft Cloud API client as there is an unknown configuration value for max_retries. "+
+				"Either target apply the source of the value first, or set the value statically in the configuration.",
+		)
+	}
+
+	if data.MaxRequestsPerSecond.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_requests_per_second"),
+			"Unknown Maximum Request Rate",
+			"The provider cannot create the Unikraft Cloud API client as there is an unknown configuration value for max_requests_per_second. "+
+				"Either target apply the source of the value first, or set the value statically in the configuration.",
+		)
+	}
+
+	if data.SkipCredentialsValidation.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("skip_credentials_validation"),
+			"Unknown Credentials Validation Setting",
+			"The provider cannot create the Unikraft Cloud API client as there is an unknown configuration value for skip_credentials_validation. "+
+				"Either target apply the source of the value first, or set the value statically in the configuration.",
+		)
+	}
+
+	if data.UserAgentSuffix.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("user_agent_suffix"),
+			"Unknown User-Agent Suffix",
+			"The provider cannot create the Unikraft Cloud API client as there is an unknown configuration value for user_agent_suffix. "+
+				"Either target apply the source of the value first, or set the value statically in the configuration.",
+		)
+	}
+
+	if data.ProxyURL.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("proxy_url"),
+			"Unknown HTTP Proxy URL",
+			"The provider cannot create the Unikraft Cloud API client as there is an unknown configuration value for the HTTP proxy URL. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the standard proxy environment variables.",
+		)
+	}
+
+	if data.Profile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("profile"),
+			"Unknown kraft CLI Profile",
+			"The provider cannot read the kraft CLI configuration file as there is an unknown configuration value for the profile. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the UKC_PROFILE environment variable.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -109,14 +297,113 @@ func (p *UnikraftCloudProvider) Configure(ctx context.Context, req provider.Conf
 	// Consider values from environment variables, but override with explicit
 	// configuration values when provided.
 
-	metro := os.Getenv("UKC_METRO")
-	if !data.Metro.IsNull() {
-		metro = data.Metro.ValueString()
+	metro, metroSource := configValue(data.Metro, "UKC_METRO")
+	token, tokenSource := configValue(data.Token, "UKC_TOKEN")
+	configFile, configFileSource := configValue(data.ConfigFile, "UKC_CONFIG_FILE")
+	profile, profileSource := configValue(data.Profile, "UKC_PROFILE")
+	endpoint, endpointSource := configValue(data.Endpoint, "UKC_ENDPOINT")
+	caCertFile, caCertFileSource := configValue(data.CACertFile, "UKC_CA_CERT_FILE")
+
+	httpCfg := httpClientConfig{
+		CACertFile:         caCertFile,
+		InsecureSkipVerify: data.InsecureSkipVerify.ValueBool(),
+
+		MaxRetries:           defaultMaxRetries,
+		MaxRequestsPerSecond: defaultMaxRequestsPerSecond,
+	}
+	if !data.MaxRetries.IsNull() {
+		httpCfg.MaxRetries = int(data.MaxRetries.ValueInt64())
+	}
+	if !data.MaxRequestsPerSecond.IsNull() {
+		httpCfg.MaxRequestsPerSecond = float64(data.MaxRequestsPerSecond.ValueInt64())
+	}
+
+	if endpoint != "" {
+		// The placeholder is not valid in a host name, substitute it for the
+		// validation only.
+		if _, err := parseURL(strings.ReplaceAll(endpoint, "{metro}", platform.DefaultMetro), "http", "https"); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("endpoint"),
+				"Invalid Unikraft Cloud API Endpoint",
+				fmt.Sprintf("The Unikraft Cloud API endpoint %q set by the %s is not a valid URL, got error: %v", endpoint, endpointSource, err),
+			)
+		}
+	}
+
+	if !data.ProxyURL.IsNull() {
+		u, err := parseURL(data.ProxyURL.ValueString(), "http", "https", "socks5")
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("proxy_url"),
+				"Invalid HTTP Proxy URL",
+				fmt.Sprintf("The HTTP proxy URL %q is not a valid URL, got error: %v", data.ProxyURL.ValueString(), err),
+			)
+		}
+		httpCfg.ProxyURL = u
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	httpClient, err := newHTTPClient(httpCfg)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ca_cert_file"),
+			"Invalid CA Certificate File",
+			fmt.Sprintf("The provider cannot read the CA certificate file %s set by the %s, got error: %v", caCertFile, caCertFileSource, err),
+		)
+		return
+	}
+
+	// Fall back to the credentials the kraft CLI was logged in with. The file
+	// and profile only need to exist when they were set explicitly.
+
+	if configFile == "" {
+		configFile = defaultCLIConfigFile()
+	}
+	if profile == "" {
+		profile = defaultCLIProfile
 	}
 
-	token := os.Getenv("UKC_TOKEN")
-	if !data.Token.IsNull() {
-		token = data.Token.ValueString()
+	if (metro == "" || token == "") && configFile != "" {
+		cli, err := loadCLIProfile(configFile, profile)
+		switch {
+		case err == nil:
+			source := fmt.Sprintf("profile %q of %s", profile, configFile)
+			if metro == "" && cli.Metro != "" {
+				metro, metroSource = cli.Metro, source
+			}
+			if token == "" && cli.APIToken() != "" {
+				token, tokenSource = cli.APIToken(), source
+			}
+		case errors.Is(err, errCLIProfileNotFound) && profileSource == "",
+			errors.Is(err, fs.ErrNotExist) && configFileSource == "" && profileSource == "":
+			// The kraft CLI is not logged in, or not used at all.
+		case errors.Is(err, errCLIProfileNotFound):
+			resp.Diagnostics.AddAttributeError(
+				path.Root("profile"),
+				"Missing kraft CLI Profile",
+				fmt.Sprintf("The profile %q set by the %s does not exist in the kraft CLI configuration file %s. "+
+					"Log in with the kraft CLI, or set a profile which exists in the file.", profile, profileSource, configFile),
+			)
+		default:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("config_file"),
+				"Invalid kraft CLI Configuration File",
+				fmt.Sprintf("The provider cannot read the kraft CLI configuration file %s, got error: %v", configFile, err),
+			)
+		}
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A custom endpoint does not necessarily serve multiple metros, so the
+	// metro is only used to name and select them.
+	if metro == "" && endpoint != "" {
+		metro, metroSource = platform.DefaultMetro, "default"
 	}
 
 	// If any of the expected configurations are still missing at this point,
@@ -127,7 +414,8 @@ func (p *UnikraftCloudProvider) Configure(ctx context.Context, req provider.Conf
 			path.Root("metro"),
 			"Missing Unikraft Cloud API Metro",
 			"The provider cannot create the Unikraft Cloud API client as there is a missing or empty configuration value for the Unikraft Cloud API metro. "+
-				"Set the metro value in the configuration or use the UKC_METRO environment variable.",
+				"Set the metro value in the configuration, use the UKC_METRO environment variable, "+
+				fmt.Sprintf("or set the metro of the %q profile of the kraft CLI configuration file.", profile),
 		)
 	}
 
@@ -136,7 +424,8 @@ func (p *UnikraftCloudProvider) Configure(ctx context.Context, req provider.Conf
 			path.Root("token"),
 			"Missing Unikraft Cloud API Token",
 			"The provider cannot create the Unikraft Cloud API client as there is a missing or empty configuration value for the Unikraft Cloud API token. "+
-				"Set the token value in the configuration or use the UKC_TOKEN environment variable.",
+				"Set the token value in the configuration, use the UKC_TOKEN environment variable, "+
+				fmt.Sprintf("or log in with the kraft CLI to store a token in the %q profile of its configuration file.", profile),
 		)
 	}
 
@@ -144,8 +433,18 @@ func (p *UnikraftCloudProvider) Configure(ctx context.Context, req provider.Conf
 		return
 	}
 
+	tflog.Info(ctx, "Configured Unikraft Cloud API client", map[string]any{
+		"metro":        metro,
+		"metro_source": metroSource,
+		"token_source": tokenSource,
+		"endpoint":     endpoint,
+	})
+
 	// Client configuration for data sources and resources
-	var clientOpts []platform.ClientOption
+	clientOpts := []platform.ClientOption{
+		platform.WithHTTPClient(httpClient),
+		platform.WithUserAgent(userAgent(p.version, req.TerraformVersion, data.UserAgentSuffix.ValueString())),
+	}
 	if metro != "" {
 		clientOpts = append(clientOpts, platform.WithDefaultMetro(metro))
 	}
@@ -153,10 +452,18 @@ func (p *UnikraftCloudProvider) Configure(ctx context.Context, req provider.Conf
 		clientOpts = append(clientOpts, platform.WithToken(token))
 	}
 
-	client := platform.NewClient(clientOpts...)
+	pool := clients.NewPool(platform.NewClient(clientOpts...), metro, endpoint)
+
+	// Report a wrong token or metro now rather than halfway through an apply.
+	if !data.SkipCredentialsValidation.ValueBool() {
+		resp.Diagnostics.Append(validateCredentials(ctx, pool.Get(metro), metro, endpoint)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	resp.DataSourceData = pool
+	resp.ResourceData = pool
 }
 
 // Resources describes the provider data model.
@@ -165,6 +472,9 @@ func (p *UnikraftCloudProvider) Resources(ctx context.Context) []func() resource
 		iresource.NewInstanceResource,
 		iresource.NewCertificateResource,
 		iresource.NewVolumeResource,
+		iresource.NewVolumeAttachmentResource,
+		iresource.NewServiceGroupResource,
+		iresource.NewAutoscaleConfigurationResource,
 	}
 }
 
@@ -175,5 +485,34 @@ func (p *UnikraftCloudProvider) DataSources(ctx context.Context) []func() dataso
 		idatasource.NewInstancesDataSource,
 		idatasource.NewVolumeDataSource,
 		idatasource.NewVolumesDataSource,
+		idatasource.NewAutoscaleConfigurationDataSource,
+		idatasource.NewImageDataSource,
+		idatasource.NewImagesDataSource,
+	}
+}
+
+// configValue returns the value of a provider attribute, or of the given
+// environment variable if the attribute is not set, along with a description
+// of where the value came from. Both are empty if neither is set.
+func configValue(attr types.String, env string) (string, string) {
+	if !attr.IsNull() {
+		return attr.ValueString(), "provider configuration"
+	}
+	if v := os.Getenv(env); v != "" {
+		return v, env + " environment variable"
+	}
+	return "", ""
+}
+
+// userAgent returns the User-Agent header identifying API requests sent by
+// this version of the provider on behalf of the given version of Terraform.
+func userAgent(version, terraformVersion, suffix string) string {
+	ua := "terraform-provider-ukc/" + version
+	if terraformVersion != "" {
+		ua += " terraform/" + terraformVersion
+	}
+	if suffix = strings.TrimSpace(suffix); suffix != "" {
+		ua += " " + suffix
 	}
+	return ua
 }
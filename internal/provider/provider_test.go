@@ -2,3 +2,59 @@
 // SPDX-License-Identifier: MPL-2.0
 
 package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserAgent(t *testing.T) {
+	assert.Equal(t, "terraform-provider-ukc/1.2.3 terraform/1.9.0", userAgent("1.2.3", "1.9.0", ""))
+	assert.Equal(t, "terraform-provider-ukc/1.2.3 terraform/1.9.0 ci/deploy", userAgent("1.2.3", "1.9.0", " ci/deploy "))
+	assert.Equal(t, "terraform-provider-ukc/dev", userAgent("dev", "", ""))
+}
+
+func TestConfigure_UnknownConfig(t *testing.T) {
+	ctx := context.Background()
+	p := New("test")()
+
+	var schemaResp provider.SchemaResponse
+	p.Schema(ctx, provider.SchemaRequest{}, &schemaResp)
+
+	// Only the token is set, to a value which is not known yet.
+	typ := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	vals := make(map[string]tftypes.Value, len(typ.AttributeTypes))
+	for name, attrType := range typ.AttributeTypes {
+		vals[name] = tftypes.NewValue(attrType, nil)
+	}
+	vals["token"] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+
+	req := provider.ConfigureRequest{
+		Config: tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(typ, vals),
+		},
+	}
+
+	req.ClientCapabilities.DeferralAllowed = true
+	var resp provider.ConfigureResponse
+	p.Configure(ctx, req, &resp)
+	assert.False(t, resp.Diagnostics.HasError())
+	if assert.NotNil(t, resp.Deferred) {
+		assert.Equal(t, provider.DeferredReasonProviderConfigUnknown, resp.Deferred.Reason)
+	}
+	assert.Nil(t, resp.ResourceData)
+
+	req.ClientCapabilities.DeferralAllowed = false
+	resp = provider.ConfigureResponse{}
+	p.Configure(ctx, req, &resp)
+	assert.Nil(t, resp.Deferred)
+	if assert.Len(t, resp.Diagnostics, 1) {
+		assert.Equal(t, "Unknown Unikraft Cloud API Token", resp.Diagnostics[0].Summary())
+	}
+}
@@ -0,0 +1,701 @@
+// Copyright (c) Unikraft GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/unikraft-cloud/terraform-provider-unikraft-cloud/internal/provider/clients"
+	models "github.com/unikraft-cloud/terraform-provider-unikraft-cloud/internal/provider/model"
+
+	"unikraft.com/cloud/sdk/platform"
+)
+
+func NewAutoscaleConfigurationResource() resource.Resource {
+	return &AutoscaleConfigurationResource{}
+}
+
+// AutoscaleConfigurationResource defines the resource implementation.
+type AutoscaleConfigurationResource struct {
+	clients *clients.Pool
+}
+
+// Ensure AutoscaleConfigurationResource satisfies various resource interfaces.
+var (
+	_ resource.Resource                = &AutoscaleConfigurationResource{}
+	_ resource.ResourceWithImportState = &AutoscaleConfigurationResource{}
+	_ resource.ResourceWithModifyPlan  = &AutoscaleConfigurationResource{}
+)
+
+// AutoscaleConfigurationResourceModel describes the resource data model.
+type AutoscaleConfigurationResourceModel struct {
+	Metro types.String `tfsdk:"metro"`
+
+	models.AutoscaleConfigurationModel
+}
+
+// replacesAutoscaleConfiguration completes the description of the attributes
+// which require the autoscale configuration to be replaced. The API has no way
+// to update the sizing of an existing configuration: it can only be created and
+// deleted as a whole, policies included.
+const replacesAutoscaleConfiguration = "Changing it replaces the configuration, which recreates its policies as well."
+
+// autoscaleUnconfigured is the status reported for a service group which has
+// no autoscale configuration.
+const autoscaleUnconfigured = platform.ResponseStatus(platform.GetAutoscaleConfigurationsResponseStatusUnconfigured)
+
+// Metadata implements resource.Resource.
+func (r *AutoscaleConfigurationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_autoscale_configuration"
+}
+
+// Schema implements resource.Resource.
+func (r *AutoscaleConfigurationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the autoscale configuration of a Unikraft Cloud service group, which adds " +
+			"and removes instances of the service group based on its load.",
+
+		Attributes: map[string]schema.Attribute{
+			"metro": metroAttribute(),
+			"service_group_uuid": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "UUID of the service group to autoscale.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"min_size": schema.Int64Attribute{
+				Optional: true,
+				Computed: true,
+				MarkdownDescription: "Minimum number of instances in the service group. " +
+					replacesAutoscaleConfiguration,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplaceIfConfigured(),
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"max_size": schema.Int64Attribute{
+				Optional: true,
+				Computed: true,
+				MarkdownDescription: "Maximum number of instances in the service group. " +
+					replacesAutoscaleConfiguration,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+					int64validator.AtLeastSumOf(path.MatchRoot("min_size")),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplaceIfConfigured(),
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"warmup_time_ms": schema.Int64Attribute{
+				Optional: true,
+				Computed: true,
+				MarkdownDescription: "Time in milliseconds given to a new instance to start before it is taken into account for scaling decisions. " +
+					replacesAutoscaleConfiguration,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplaceIfConfigured(),
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"cooldown_time_ms": schema.Int64Attribute{
+				Optional: true,
+				Computed: true,
+				MarkdownDescription: "Time in milliseconds to wait after a scaling action before scaling again. " +
+					replacesAutoscaleConfiguration,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplaceIfConfigured(),
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"template_instance_uuid": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				MarkdownDescription: "UUID of the instance which new instances are created from. " +
+					replacesAutoscaleConfiguration,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"policies": schema.ListNestedAttribute{
+				Optional: true,
+				MarkdownDescription: "Scaling policies of the configuration. Policies can be added, changed and " +
+					"removed without replacing the configuration.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Name of the policy, unique within the configuration.",
+						},
+						// Prior values are carried over by ModifyPlan, which
+						// matches policies by name rather than by position.
+						"enabled": schema.BoolAttribute{
+							Optional:            true,
+							Computed:            true,
+							MarkdownDescription: "Whether the policy is enabled.",
+						},
+						"metric": schema.StringAttribute{
+							Optional:            true,
+							Computed:            true,
+							MarkdownDescription: "Metric the policy reacts to (`cpu`).",
+							Validators: []validator.String{
+								stringvalidator.OneOf(string(platform.AutoscalePolicyMetricCpu)),
+							},
+						},
+						"adjustment_type": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "How the adjustment of a step is applied (`change`, `exact` or `percentage`).",
+							Validators: []validator.String{
+								stringvalidator.OneOf(
+									string(platform.AutoscalePolicyAdjustmentTypeChange),
+									string(platform.AutoscalePolicyAdjustmentTypeExact),
+									string(platform.AutoscalePolicyAdjustmentTypePercentage),
+								),
+							},
+						},
+						"steps": schema.ListNestedAttribute{
+							Required:            true,
+							MarkdownDescription: "Steps of the policy, each applying an adjustment while the metric is within its bounds.",
+							Validators: []validator.List{
+								listvalidator.SizeAtLeast(1),
+							},
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"adjustment": schema.Int64Attribute{
+										Required:            true,
+										MarkdownDescription: "Adjustment applied to the number of instances.",
+									},
+									"lower_bound": schema.Int64Attribute{
+										Optional:            true,
+										MarkdownDescription: "Lower bound of the metric for this step. Unbounded when not set.",
+									},
+									"upper_bound": schema.Int64Attribute{
+										Optional:            true,
+										MarkdownDescription: "Upper bound of the metric for this step. Unbounded when not set.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure implements resource.Resource.
+func (r *AutoscaleConfigurationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	pool, ok := req.ProviderData.(*clients.Pool)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *clients.Pool, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.clients = pool
+}
+
+// Create implements resource.Resource.
+func (r *AutoscaleConfigurationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data AutoscaleConfigurationResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	in := platform.CreateAutoscaleConfigurationByServiceGroupUUIDRequest{
+		MinSize:        knownInt64(data.MinSize),
+		MaxSize:        knownInt64(data.MaxSize),
+		WarmupTimeMs:   knownInt64(data.WarmupTimeMs),
+		CooldownTimeMs: knownInt64(data.CooldownTimeMs),
+	}
+
+	if !data.TemplateInstanceUUID.IsNull() && !data.TemplateInstanceUUID.IsUnknown() {
+		in.CreateArgs = &platform.CreateAutoscaleConfigurationByServiceGroupUUIDRequestCreateArgs{
+			Template: &platform.NameOrUUID{Uuid: data.TemplateInstanceUUID.ValueStringPointer()},
+		}
+	}
+
+	for _, p := range data.Policies {
+		in.Policies = append(in.Policies, platformAutoscalePolicy(p))
+	}
+
+	client := r.clients.Client(&data.Metro)
+
+	asResp, err := client.CreateAutoscaleConfigurationByServiceGroupUUID(ctx, data.ServiceGroupUUID.ValueString(), in)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Failed to create autoscale configuration, got error: %v", err),
+		)
+		return
+	}
+
+	if asResp == nil || asResp.Data == nil || len(asResp.Data.ServiceGroups) == 0 {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			"Empty response from create autoscale configuration API",
+		)
+		return
+	}
+	if sg := asResp.Data.ServiceGroups[0]; sg.Error != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Failed to create autoscale configuration, got error: %s", stringValue(sg.Message)),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(readAutoscaleConfigurationState(ctx, client, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read implements resource.Resource.
+func (r *AutoscaleConfigurationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data AutoscaleConfigurationResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.clients.Client(&data.Metro)
+
+	diags := readAutoscaleConfigurationState(ctx, client, &data)
+	if hasNotFound(diags) {
+		// The configuration was deleted out-of-band, let Terraform recreate it.
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// ModifyPlan implements resource.ResourceWithModifyPlan. The values of
+// policies left for the platform to decide are taken from the policy with the
+// same name in the prior state, so that adding, removing or reordering
+// policies does not change the others.
+func (r *AutoscaleConfigurationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPlanMetro(ctx, r.clients, req, resp)
+
+	// Nothing to do on creation and destruction.
+	if resp.Diagnostics.HasError() || req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state types.List
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("policies"), &plan)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("policies"), &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policies, diags := autoscalePoliciesFromState(plan, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("policies"), policies)...)
+}
+
+// Update implements resource.Resource.
+//
+// All other attributes require a replacement, as the API cannot update an
+// existing configuration, so only the policies of the configuration are
+// updated here. Changed policies are deleted and created
+// again, since the API does not provide a way to modify them.
+func (r *AutoscaleConfigurationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan AutoscaleConfigurationResourceModel
+	var state AutoscaleConfigurationResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.clients.Client(&plan.Metro)
+
+	sgUUID := state.ServiceGroupUUID.ValueString()
+	remove, add := autoscalePolicyChanges(plan.Policies, state.Policies)
+
+	for _, name := range remove {
+		if _, err := client.DeleteAutoscaleConfigurationPolicyByName(ctx, sgUUID, name); err != nil && !isNotFound(err) {
+			resp.Diagnostics.AddError(
+				"Client Error",
+				fmt.Sprintf("Failed to delete autoscale policy %s, got error: %v", name, err),
+			)
+			return
+		}
+	}
+
+	for _, p := range add {
+		policy := platformAutoscalePolicy(p)
+		in := platform.CreateAutoscaleConfigurationPolicyRequest{
+			Name: p.Name.ValueString(),
+			Type: platform.CreateAutoscaleConfigurationPolicyRequestType{
+				Name:    policy.Name,
+				Enabled: policy.Enabled,
+				Steps:   policy.Steps,
+			},
+		}
+		if policy.Metric != nil {
+			metric := platform.CreateAutoscaleConfigurationPolicyRequestTypeMetric(*policy.Metric)
+			in.Type.Metric = &metric
+		}
+		if policy.AdjustmentType != nil {
+			adjustmentType := platform.CreateAutoscaleConfigurationPolicyRequestTypeAdjustmentType(*policy.AdjustmentType)
+			in.Type.AdjustmentType = &adjustmentType
+		}
+
+		if _, err := client.CreateAutoscaleConfigurationPolicy(ctx, sgUUID, in); err != nil {
+			resp.Diagnostics.AddError(
+				"Client Error",
+				fmt.Sprintf("Failed to create autoscale policy %s, got error: %v", p.Name.ValueString(), err),
+			)
+			return
+		}
+	}
+
+	// Re-read full state after update
+	data := plan
+	resp.Diagnostics.Append(readAutoscaleConfigurationState(ctx, client, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete implements resource.Resource.
+func (r *AutoscaleConfigurationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data AutoscaleConfigurationResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.clients.Client(&data.Metro)
+
+	_, err := client.DeleteAutoscaleConfigurationsByServiceGroupUUID(ctx, data.ServiceGroupUUID.ValueString())
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Failed to delete autoscale configuration, got error: %v", err),
+		)
+		return
+	}
+}
+
+// ImportState implements resource.ResourceWithImportState.
+func (r *AutoscaleConfigurationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importStateWithMetro(ctx, path.Root("service_group_uuid"), req, resp)
+}
+
+// readAutoscaleConfigurationState fetches the autoscale configuration of the
+// service group from the API and populates the model.
+func readAutoscaleConfigurationState(ctx context.Context, client platform.Client, data *AutoscaleConfigurationResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	sgUUID := data.ServiceGroupUUID.ValueString()
+
+	asResp, err := client.GetAutoscaleConfigurationsByServiceGroupUUID(ctx, sgUUID)
+	if isNotFound(err) {
+		diags.Append(newNotFoundDiagnostic("autoscale configuration of service group", sgUUID))
+		return diags
+	}
+	if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Failed to get autoscale configuration, got error: %v", err),
+		)
+		return diags
+	}
+
+	if asResp == nil || asResp.Data == nil || len(asResp.Data.ServiceGroups) == 0 {
+		diags.AddError(
+			"Client Error",
+			"Empty response from get autoscale configuration API",
+		)
+		return diags
+	}
+	sg := asResp.Data.ServiceGroups[0]
+
+	if isNotFoundCode(sg.Error) || (sg.Status != nil && *sg.Status == autoscaleUnconfigured) {
+		diags.Append(newNotFoundDiagnostic("autoscale configuration of service group", sgUUID))
+		return diags
+	}
+	if sg.Error != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Failed to get autoscale configuration, got error: %s", stringValue(sg.Message)),
+		)
+		return diags
+	}
+
+	readAutoscaleConfiguration(sg, &data.AutoscaleConfigurationModel)
+
+	return diags
+}
+
+// readAutoscaleConfiguration populates the model from the autoscale
+// configuration reported by the API. Policies keep the order in which they
+// are declared, policies unknown to the model are appended.
+func readAutoscaleConfiguration(sg platform.GetAutoscaleConfigurationsResponseServiceGroup, data *models.AutoscaleConfigurationModel) {
+	data.MinSize = types.Int64PointerValue(sg.MinSize)
+	data.MaxSize = types.Int64PointerValue(sg.MaxSize)
+	data.WarmupTimeMs = types.Int64PointerValue(sg.WarmupTimeMs)
+	data.CooldownTimeMs = types.Int64PointerValue(sg.CooldownTimeMs)
+
+	data.TemplateInstanceUUID = types.StringNull()
+	if sg.Template != nil && sg.Template.Uuid != nil {
+		data.TemplateInstanceUUID = types.StringValue(*sg.Template.Uuid)
+	}
+
+	apiPolicies := make(map[string]platform.AutoscalePolicy, len(sg.Policies))
+	for _, p := range sg.Policies {
+		if p.Name != nil {
+			apiPolicies[*p.Name] = p
+		}
+	}
+
+	// Retain an empty list of policies, while keeping them null when unset.
+	policies := data.Policies[:0:0]
+	for _, p := range data.Policies {
+		if apiPolicy, ok := apiPolicies[p.Name.ValueString()]; ok {
+			policies = append(policies, autoscalePolicyModel(apiPolicy))
+			delete(apiPolicies, p.Name.ValueString())
+		}
+	}
+	for _, p := range sg.Policies {
+		if p.Name == nil {
+			continue
+		}
+		if _, ok := apiPolicies[*p.Name]; ok {
+			policies = append(policies, autoscalePolicyModel(p))
+		}
+	}
+	data.Policies = policies
+}
+
+// autoscalePolicyModel converts a scaling policy returned by the API to its
+// data model.
+func autoscalePolicyModel(p platform.AutoscalePolicy) models.AutoscalePolicyModel {
+	out := models.AutoscalePolicyModel{
+		Name:           types.StringPointerValue(p.Name),
+		Enabled:        types.BoolPointerValue(p.Enabled),
+		Metric:         types.StringNull(),
+		AdjustmentType: types.StringNull(),
+	}
+	if p.Metric != nil {
+		out.Metric = types.StringValue(string(*p.Metric))
+	}
+	if p.AdjustmentType != nil {
+		out.AdjustmentType = types.StringValue(string(*p.AdjustmentType))
+	}
+
+	out.Steps = make([]models.AutoscaleStepModel, len(p.Steps))
+	for i, s := range p.Steps {
+		out.Steps[i] = models.AutoscaleStepModel{
+			Adjustment: types.Int64PointerValue(s.Adjustment),
+			LowerBound: types.Int64PointerValue(s.LowerBound),
+			UpperBound: types.Int64PointerValue(s.UpperBound),
+		}
+	}
+	return out
+}
+
+// platformAutoscalePolicy converts the data model of a scaling policy to the
+// policy expected by the API. Values which are not known yet are left for the
+// platform to decide.
+func platformAutoscalePolicy(p models.AutoscalePolicyModel) platform.AutoscalePolicy {
+	out := platform.AutoscalePolicy{
+		Name: p.Name.ValueStringPointer(),
+	}
+	if !p.Enabled.IsNull() && !p.Enabled.IsUnknown() {
+		out.Enabled = p.Enabled.ValueBoolPointer()
+	}
+	if !p.Metric.IsNull() && !p.Metric.IsUnknown() {
+		metric := platform.AutoscalePolicyMetric(p.Metric.ValueString())
+		out.Metric = &metric
+	}
+	if !p.AdjustmentType.IsNull() && !p.AdjustmentType.IsUnknown() {
+		adjustmentType := platform.AutoscalePolicyAdjustmentType(p.AdjustmentType.ValueString())
+		out.AdjustmentType = &adjustmentType
+	}
+
+	out.Steps = make([]platform.AutoscalePolicyStep, len(p.Steps))
+	for i, s := range p.Steps {
+		out.Steps[i] = platform.AutoscalePolicyStep{
+			Adjustment: knownInt64(s.Adjustment),
+			LowerBound: knownInt64(s.LowerBound),
+			UpperBound: knownInt64(s.UpperBound),
+		}
+	}
+	return out
+}
+
+// autoscalePolicyChanges returns the names of the policies which have to be
+// deleted and the policies which have to be created to bring the policies of
+// an autoscale configuration from their prior state to the planned one.
+// Policies are matched by name, and replaced when any of their settings
+// changed.
+func autoscalePolicyChanges(plan, state []models.AutoscalePolicyModel) (remove []string, add []models.AutoscalePolicyModel) {
+	prior := make(map[string]platform.AutoscalePolicy, len(state))
+	for _, p := range state {
+		prior[p.Name.ValueString()] = platformAutoscalePolicy(p)
+	}
+
+	planned := make(map[string]bool, len(plan))
+	for _, p := range plan {
+		name := p.Name.ValueString()
+		planned[name] = true
+
+		old, ok := prior[name]
+		if ok && reflect.DeepEqual(old, platformAutoscalePolicy(p)) {
+			continue
+		}
+		if ok {
+			remove = append(remove, name)
+		}
+		add = append(add, p)
+	}
+
+	for _, p := range state {
+		if !planned[p.Name.ValueString()] {
+			remove = append(remove, p.Name.ValueString())
+		}
+	}
+
+	return remove, add
+}
+
+// autoscalePolicyComputed are the attributes of a policy which are left for
+// the platform to decide when they are not configured.
+var autoscalePolicyComputed = []string{"enabled", "metric"}
+
+// autoscalePoliciesFromState returns the planned policies with the unknown
+// values of autoscalePolicyComputed replaced by the ones of the policy with
+// the same name in the prior state.
+func autoscalePoliciesFromState(plan, state types.List) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if plan.IsNull() || plan.IsUnknown() || state.IsNull() || state.IsUnknown() {
+		return plan, diags
+	}
+
+	prior := make(map[string]map[string]attr.Value, len(state.Elements()))
+	for _, e := range state.Elements() {
+		obj, ok := e.(types.Object)
+		if !ok || obj.IsNull() || obj.IsUnknown() {
+			continue
+		}
+		if name, ok := obj.Attributes()["name"].(types.String); ok {
+			prior[name.ValueString()] = obj.Attributes()
+		}
+	}
+
+	elems := make([]attr.Value, len(plan.Elements()))
+	for i, e := range plan.Elements() {
+		elems[i] = e
+
+		obj, ok := e.(types.Object)
+		if !ok || obj.IsNull() || obj.IsUnknown() {
+			continue
+		}
+		name, ok := obj.Attributes()["name"].(types.String)
+		if !ok || name.IsUnknown() {
+			continue
+		}
+		old, ok := prior[name.ValueString()]
+		if !ok {
+			continue
+		}
+
+		attrs := maps.Clone(obj.Attributes())
+		for _, k := range autoscalePolicyComputed {
+			if attrs[k].IsUnknown() {
+				attrs[k] = old[k]
+			}
+		}
+
+		var d diag.Diagnostics
+		elems[i], d = types.ObjectValue(obj.AttributeTypes(context.Background()), attrs)
+		diags.Append(d...)
+	}
+	if diags.HasError() {
+		return plan, diags
+	}
+
+	out, d := types.ListValue(plan.ElementType(context.Background()), elems)
+	diags.Append(d...)
+	return out, diags
+}
+
+// knownInt64 returns a pointer to the value of v, or nil when v is null or
+// unknown.
+func knownInt64(v types.Int64) *int64 {
+	if v.IsNull() || v.IsUnknown() {
+		return nil
+	}
+	return v.ValueInt64Pointer()
+}
+
+// stringValue returns the string pointed to by s, or an empty string when s
+// is nil.
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
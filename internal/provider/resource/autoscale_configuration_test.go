@@ -0,0 +1,219 @@
+// Copyright (c) Unikraft GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	models "github.com/unikraft-cloud/terraform-provider-unikraft-cloud/internal/provider/model"
+
+	"unikraft.com/cloud/sdk/platform"
+)
+
+// cpuPolicy returns a CPU scaling policy which adds an instance above the
+// given load.
+func cpuPolicy(name string, lowerBound int64) models.AutoscalePolicyModel {
+	return models.AutoscalePolicyModel{
+		Name:           types.StringValue(name),
+		Enabled:        types.BoolValue(true),
+		Metric:         types.StringValue("cpu"),
+		AdjustmentType: types.StringValue("change"),
+		Steps: []models.AutoscaleStepModel{{
+			Adjustment: types.Int64Value(1),
+			LowerBound: types.Int64Value(lowerBound),
+			UpperBound: types.Int64Null(),
+		}},
+	}
+}
+
+func TestPlatformAutoscalePolicy(t *testing.T) {
+	p := cpuPolicy("scale-up", 60)
+	p.Enabled = types.BoolUnknown()
+	p.Metric = types.StringUnknown()
+
+	got := platformAutoscalePolicy(p)
+
+	assert.Equal(t, "scale-up", *got.Name)
+	assert.Nil(t, got.Enabled)
+	assert.Nil(t, got.Metric)
+	assert.Equal(t, platform.AutoscalePolicyAdjustmentTypeChange, *got.AdjustmentType)
+	if assert.Len(t, got.Steps, 1) {
+		assert.Equal(t, int64(1), *got.Steps[0].Adjustment)
+		assert.Equal(t, int64(60), *got.Steps[0].LowerBound)
+		assert.Nil(t, got.Steps[0].UpperBound)
+	}
+}
+
+func TestAutoscalePolicyChanges(t *testing.T) {
+	state := []models.AutoscalePolicyModel{cpuPolicy("a", 60), cpuPolicy("b", 80), cpuPolicy("c", 90)}
+	plan := []models.AutoscalePolicyModel{cpuPolicy("a", 60), cpuPolicy("b", 70), cpuPolicy("d", 95)}
+
+	remove, add := autoscalePolicyChanges(plan, state)
+
+	assert.Equal(t, []string{"b", "c"}, remove)
+	if assert.Len(t, add, 2) {
+		assert.Equal(t, "b", add[0].Name.ValueString())
+		assert.Equal(t, "d", add[1].Name.ValueString())
+	}
+
+	remove, add = autoscalePolicyChanges(state, state)
+	assert.Empty(t, remove)
+	assert.Empty(t, add)
+}
+
+func TestReadAutoscaleConfiguration(t *testing.T) {
+	name := func(s string) *string { return &s }
+	minSize, maxSize := int64(1), int64(4)
+	templateUUID := "template-uuid"
+	metric := platform.AutoscalePolicyMetricCpu
+	adjustmentType := platform.AutoscalePolicyAdjustmentTypeChange
+	adjustment, lowerBound := int64(1), int64(60)
+	apiPolicy := func(n string) platform.AutoscalePolicy {
+		return platform.AutoscalePolicy{
+			Name:           name(n),
+			Metric:         &metric,
+			AdjustmentType: &adjustmentType,
+			Steps:          []platform.AutoscalePolicyStep{{Adjustment: &adjustment, LowerBound: &lowerBound}},
+		}
+	}
+
+	sg := platform.GetAutoscaleConfigurationsResponseServiceGroup{
+		MinSize:  &minSize,
+		MaxSize:  &maxSize,
+		Template: &platform.GetAutoscaleConfigurationsResponseServiceGroupTemplate{Uuid: &templateUUID},
+		Policies: []platform.AutoscalePolicy{apiPolicy("extra"), apiPolicy("declared")},
+	}
+	data := models.AutoscaleConfigurationModel{
+		Policies: []models.AutoscalePolicyModel{cpuPolicy("gone", 60), cpuPolicy("declared", 60)},
+	}
+
+	readAutoscaleConfiguration(sg, &data)
+
+	assert.Equal(t, int64(1), data.MinSize.ValueInt64())
+	assert.Equal(t, int64(4), data.MaxSize.ValueInt64())
+	assert.True(t, data.WarmupTimeMs.IsNull())
+	assert.Equal(t, "template-uuid", data.TemplateInstanceUUID.ValueString())
+	if assert.Len(t, data.Policies, 2) {
+		assert.Equal(t, "declared", data.Policies[0].Name.ValueString())
+		assert.Equal(t, "extra", data.Policies[1].Name.ValueString())
+		assert.Equal(t, "cpu", data.Policies[0].Metric.ValueString())
+		assert.True(t, data.Policies[0].Enabled.IsNull())
+		assert.Equal(t, int64(60), data.Policies[0].Steps[0].LowerBound.ValueInt64())
+	}
+}
+
+func TestReadAutoscaleConfiguration_NoPolicies(t *testing.T) {
+	data := models.AutoscaleConfigurationModel{Policies: []models.AutoscalePolicyModel{}}
+	readAutoscaleConfiguration(platform.GetAutoscaleConfigurationsResponseServiceGroup{}, &data)
+	assert.NotNil(t, data.Policies, "an empty list of policies is kept")
+
+	data.Policies = nil
+	readAutoscaleConfiguration(platform.GetAutoscaleConfigurationsResponseServiceGroup{}, &data)
+	assert.Nil(t, data.Policies)
+}
+
+func TestAutoscaleConfigurationResource_Schema_Metric(t *testing.T) {
+	policies := testSchema(t, NewAutoscaleConfigurationResource()).Schema.Attributes["policies"].(schema.ListNestedAttribute)
+	metric := policies.NestedObject.Attributes["metric"].(schema.StringAttribute)
+
+	validate := func(v string) bool {
+		resp := &validator.StringResponse{}
+		for _, val := range metric.Validators {
+			val.ValidateString(context.Background(), validator.StringRequest{ConfigValue: types.StringValue(v)}, resp)
+		}
+		return !resp.Diagnostics.HasError()
+	}
+	assert.True(t, validate("cpu"))
+	assert.False(t, validate("memory"))
+}
+
+func TestAutoscaleConfigurationResource_ModifyPlan(t *testing.T) {
+	ctx := context.Background()
+	r := NewAutoscaleConfigurationResource().(*AutoscaleConfigurationResource)
+	typ := testSchema(t, r).Schema.Attributes["policies"].GetType().(types.ListType)
+
+	up := cpuPolicy("up", 80)
+	up.Enabled = types.BoolValue(false)
+	state, diags := types.ListValueFrom(ctx, typ.ElemType, []models.AutoscalePolicyModel{cpuPolicy("down", 20), up})
+	require.False(t, diags.HasError(), diags)
+
+	// The first policy was removed, which moves the second one up.
+	planned := cpuPolicy("up", 80)
+	planned.Enabled = types.BoolUnknown()
+	planned.Metric = types.StringUnknown()
+	plan, diags := types.ListValueFrom(ctx, typ.ElemType, []models.AutoscalePolicyModel{planned})
+	require.False(t, diags.HasError(), diags)
+
+	attrs := map[string]attr.Value{"metro": types.StringValue("fra0"), "service_group_uuid": types.StringValue("sg-uuid")}
+	stateData := testPlan(t, r, attrs)
+	require.False(t, stateData.SetAttribute(ctx, path.Root("policies"), state).HasError())
+	planData := testPlan(t, r, attrs)
+	require.False(t, planData.SetAttribute(ctx, path.Root("policies"), plan).HasError())
+
+	resp := &resource.ModifyPlanResponse{Plan: planData}
+	r.ModifyPlan(ctx, resource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: planData.Schema, Raw: planData.Raw},
+		Plan:   planData,
+		State:  tfsdk.State{Schema: stateData.Schema, Raw: stateData.Raw},
+	}, resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var got []models.AutoscalePolicyModel
+	require.False(t, resp.Plan.GetAttribute(ctx, path.Root("policies"), &got).HasError())
+	if assert.Len(t, got, 1) {
+		assert.Equal(t, types.BoolValue(false), got[0].Enabled, "taken from the policy of the same name")
+		assert.Equal(t, types.StringValue("cpu"), got[0].Metric)
+	}
+}
+
+func TestAutoscalePoliciesFromState(t *testing.T) {
+	ctx := context.Background()
+	typ := testSchema(t, NewAutoscaleConfigurationResource()).Schema.Attributes["policies"].GetType().(types.ListType)
+
+	policy := func(name string, enabled types.Bool, metric types.String) models.AutoscalePolicyModel {
+		return models.AutoscalePolicyModel{
+			Name:           types.StringValue(name),
+			Enabled:        enabled,
+			Metric:         metric,
+			AdjustmentType: types.StringValue("change"),
+			Steps:          []models.AutoscaleStepModel{{Adjustment: types.Int64Value(1), LowerBound: types.Int64Null(), UpperBound: types.Int64Null()}},
+		}
+	}
+
+	state, diags := types.ListValueFrom(ctx, typ.ElemType, []models.AutoscalePolicyModel{
+		policy("up", types.BoolValue(true), types.StringValue("cpu")),
+		policy("down", types.BoolValue(false), types.StringValue("cpu")),
+	})
+	require.False(t, diags.HasError(), diags)
+
+	// The first policy was removed, and another one added.
+	plan, diags := types.ListValueFrom(ctx, typ.ElemType, []models.AutoscalePolicyModel{
+		policy("down", types.BoolUnknown(), types.StringUnknown()),
+		policy("new", types.BoolUnknown(), types.StringUnknown()),
+	})
+	require.False(t, diags.HasError(), diags)
+
+	out, diags := autoscalePoliciesFromState(plan, state)
+	require.False(t, diags.HasError(), diags)
+
+	var got []models.AutoscalePolicyModel
+	require.False(t, out.ElementsAs(ctx, &got, false).HasError())
+	if assert.Len(t, got, 2) {
+		assert.Equal(t, types.BoolValue(false), got[0].Enabled)
+		assert.Equal(t, types.StringValue("cpu"), got[0].Metric)
+		assert.True(t, got[1].Enabled.IsUnknown())
+		assert.True(t, got[1].Metric.IsUnknown())
+	}
+}
@@ -5,9 +5,12 @@ package resource
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -18,6 +21,8 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 
+	"github.com/unikraft-cloud/terraform-provider-unikraft-cloud/internal/provider/clients"
+
 	"unikraft.com/cloud/sdk/platform"
 )
 
@@ -27,15 +32,17 @@ func NewCertificateResource() resource.Resource {
 
 // CertificateResource defines the resource implementation.
 type CertificateResource struct {
-	client platform.Client
+	clients *clients.Pool
 }
 
 var (
 	_ resource.Resource                = &CertificateResource{}
 	_ resource.ResourceWithImportState = &CertificateResource{}
+	_ resource.ResourceWithModifyPlan  = &CertificateResource{}
 )
 
 type CertificateResourceModel struct {
+	Metro   types.String         `tfsdk:"metro"`
 	Chain   types.String         `tfsdk:"chain"`
 	Cn      types.String         `tfsdk:"cn"`
 	Data    CertificateDataValue `tfsdk:"data"`
@@ -44,6 +51,8 @@ type CertificateResourceModel struct {
 	Pkey    types.String         `tfsdk:"pkey"`
 	Status  types.String         `tfsdk:"status"`
 	UUID    types.String         `tfsdk:"uuid"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *CertificateResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -53,6 +62,7 @@ func (r *CertificateResource) Metadata(ctx context.Context, req resource.Metadat
 func (r *CertificateResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
+			"metro": metroAttribute(),
 			"chain": schema.StringAttribute{
 				Required:            true,
 				Description:         "The chain of the certificate.",
@@ -137,6 +147,13 @@ func (r *CertificateResource) Schema(ctx context.Context, req resource.SchemaReq
 				MarkdownDescription: "The UUID of the certificate.",
 			},
 		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -146,16 +163,16 @@ func (r *CertificateResource) Configure(ctx context.Context, req resource.Config
 		return
 	}
 
-	client, ok := req.ProviderData.(platform.Client)
+	pool, ok := req.ProviderData.(*clients.Pool)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected platform.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *clients.Pool, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.clients = pool
 }
 
 func (r *CertificateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -167,6 +184,14 @@ func (r *CertificateResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.clients.Client(&data.Metro)
+
 	crt := platform.CreateCertificateRequest{
 		Cn:    data.Cn.ValueString(),
 		Chain: data.Chain.ValueString(),
@@ -179,7 +204,7 @@ func (r *CertificateResource) Create(ctx context.Context, req resource.CreateReq
 		crt.Name = &name
 	}
 
-	crtResp, err := r.client.CreateCertificate(ctx, crt)
+	crtResp, err := client.CreateCertificate(ctx, crt)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Client Error",
@@ -216,8 +241,20 @@ func (r *CertificateResource) Create(ctx context.Context, req resource.CreateReq
 	data.Status = types.StringValue(crtResp.Status)
 	data.Message = types.StringValue(crtResp.Message)
 
+	if err := waitCertificateIssued(ctx, client, *crts.Uuid, createTimeout); err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Certificate %s did not become valid, got error: %v", *crts.Uuid, err),
+		)
+		// The certificate exists although it did not become valid. Save it,
+		// so that Terraform taints it rather than creating another one on the
+		// next apply.
+		resp.Diagnostics.Append(setPartialState(ctx, &resp.State, &data)...)
+		return
+	}
+
 	// Get full certificate details
-	crtFullResp, err := r.client.GetCertificateByUUID(ctx, *crts.Uuid)
+	crtFullResp, err := client.GetCertificateByUUID(ctx, *crts.Uuid)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Client Error",
@@ -258,7 +295,6 @@ func (r *CertificateResource) Create(ctx context.Context, req resource.CreateReq
 	}
 	certificatesList = append(certificatesList, certValue)
 
-	var diags diag.Diagnostics
 	certificatesListValue, diags := types.ListValueFrom(ctx, CertificatesType{
 		ObjectType: types.ObjectType{
 			AttrTypes: CertificatesValue{}.AttributeTypes(ctx),
@@ -287,11 +323,13 @@ func (r *CertificateResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
+	client := r.clients.Client(&data.Metro)
+
 	// Get current state from the API
-	crtResp, err := r.client.GetCertificateByUUID(ctx, data.UUID.ValueString())
+	crtResp, err := client.GetCertificateByUUID(ctx, data.UUID.ValueString())
 	if err != nil {
-		// Check if error is 404 (certificate not found)
-		if strings.Contains(err.Error(), "404") || strings.Contains(err.Error(), "not found") {
+		// Check whether the certificate was deleted out-of-band
+		if isNotFound(err) {
 			// Certificate no longer exists, remove from state
 			resp.State.RemoveResource(ctx)
 			return
@@ -385,7 +423,15 @@ func (r *CertificateResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 
-	_, err := r.client.DeleteCertificateByUUID(ctx, data.UUID.ValueString())
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.clients.Client(&data.Metro)
+
+	_, err := client.DeleteCertificateByUUID(ctx, data.UUID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Client Error",
@@ -393,11 +439,65 @@ func (r *CertificateResource) Delete(ctx context.Context, req resource.DeleteReq
 		)
 		return
 	}
+
+	if err := waitCertificateDeleted(ctx, client, data.UUID.ValueString(), deleteTimeout); err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Certificate %s was not deleted, got error: %v", data.UUID.ValueString(), err),
+		)
+		return
+	}
+}
+
+// ModifyPlan implements resource.ResourceWithModifyPlan.
+func (r *CertificateResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPlanMetro(ctx, r.clients, req, resp)
 }
 
 // ImportState implements resource.ResourceWithImportState.
 func (r *CertificateResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("uuid"), req, resp)
+	importStateWithMetro(ctx, path.Root("uuid"), req, resp)
+}
+
+// waitCertificateIssued polls a certificate until it is no longer pending,
+// and fails if the platform reports the certificate in an error state.
+func waitCertificateIssued(ctx context.Context, client platform.Client, uuid string, timeout time.Duration) error {
+	return waitFor(ctx, timeout, func(ctx context.Context) (bool, error) {
+		crtResp, err := client.GetCertificateByUUID(ctx, uuid)
+		if err != nil {
+			return false, err
+		}
+		if crtResp == nil || crtResp.Data == nil || len(crtResp.Data.Certificates) == 0 {
+			return false, errors.New("empty response from get certificate API")
+		}
+		crt := crtResp.Data.Certificates[0]
+
+		if crt.State == nil {
+			return false, nil
+		}
+		switch *crt.State {
+		case platform.CertificateStateValid:
+			return true, nil
+		case platform.CertificateStateError:
+			return false, errors.New("certificate is in error state")
+		}
+		return false, nil
+	})
+}
+
+// waitCertificateDeleted polls a certificate until the platform no longer
+// knows about it.
+func waitCertificateDeleted(ctx context.Context, client platform.Client, uuid string, timeout time.Duration) error {
+	return waitFor(ctx, timeout, func(ctx context.Context) (bool, error) {
+		crtResp, err := client.GetCertificateByUUID(ctx, uuid)
+		if isNotFound(err) {
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		return crtResp == nil || crtResp.Data == nil || len(crtResp.Data.Certificates) == 0, nil
+	})
 }
 
 var _ basetypes.ObjectTypable = DataTypeCertificate{}
@@ -7,11 +7,15 @@ import (
 	"context"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 
 	providerMock "github.com/unikraft-cloud/terraform-provider-unikraft-cloud/internal/provider/mock"
+
+	"unikraft.com/cloud/sdk/platform"
 )
 
 func TestCertificateResource_Metadata(t *testing.T) {
@@ -121,3 +125,39 @@ func TestCertificateResourceModel_Basic(t *testing.T) {
 	assert.Equal(t, "cert-uuid", model.UUID.ValueString())
 	assert.Equal(t, "success", model.Status.ValueString())
 }
+
+func TestCertificateResource_Create_WaitFails(t *testing.T) {
+	ctx := context.Background()
+	mockClient := new(providerMock.PlatformClient)
+	r := &CertificateResource{clients: testPool(mockClient)}
+
+	uuid := "crt-uuid"
+	state := platform.CertificateStateError
+	mockClient.On("CreateCertificate", mock.Anything, mock.Anything).Return(&platform.Response[platform.CreateCertificateResponseData]{
+		Status: "success",
+		Data:   &platform.CreateCertificateResponseData{Certificates: []platform.Certificate{{Uuid: &uuid}}},
+	}, nil)
+	mockClient.On("GetCertificateByUUID", mock.Anything, uuid).Return(&platform.Response[platform.GetCertificatesResponseData]{
+		Status: "success",
+		Data:   &platform.GetCertificatesResponseData{Certificates: []platform.Certificate{{Uuid: &uuid, State: &state}}},
+	}, nil)
+
+	req := resource.CreateRequest{
+		Plan: testPlan(t, r, map[string]attr.Value{
+			"cn":    types.StringValue("example.com"),
+			"chain": types.StringValue("chain"),
+			"pkey":  types.StringValue("pkey"),
+			"uuid":  types.StringUnknown(),
+		}),
+	}
+	resp := &resource.CreateResponse{State: testState(t, r)}
+
+	r.Create(ctx, req, resp)
+
+	// The certificate is saved, so that Terraform taints it instead of
+	// creating another one on the next apply.
+	assert.True(t, resp.Diagnostics.HasError())
+	var got CertificateResourceModel
+	assert.False(t, resp.State.Get(ctx, &got).HasError())
+	assert.Equal(t, uuid, got.UUID.ValueString())
+}
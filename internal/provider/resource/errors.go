@@ -0,0 +1,83 @@
+// Copyright (c) Unikraft GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/unikraft-cloud/terraform-provider-unikraft-cloud/internal/provider/clients"
+
+	"unikraft.com/cloud/sdk/platform"
+)
+
+// apiErrorItem describes the error code of a single object in the data
+// element of an API response.
+type apiErrorItem struct {
+	Error *int32 `json:"error"`
+}
+
+// isNotFound reports whether err indicates that the requested object does not
+// exist on the platform, either through the HTTP status of the response or
+// through the error code of an object in its data element.
+func isNotFound(err error) bool {
+	body, ok := clients.DecodeAPIError(err)
+	if !ok {
+		return false
+	}
+
+	if body.HasStatus(http.StatusNotFound) {
+		return true
+	}
+
+	for _, raw := range body.Data {
+		var items []apiErrorItem
+		if err := json.Unmarshal(raw, &items); err != nil {
+			continue
+		}
+		for _, it := range items {
+			if isNotFoundCode(it.Error) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// isNotFoundCode reports whether the error code of an object returned by the
+// platform API indicates that the object does not exist.
+func isNotFoundCode(code *int32) bool {
+	return code != nil && platform.APIHTTPError(*code) == platform.APIHTTPErrorNotFound
+}
+
+// notFoundDiagnostic is the error diagnostic returned when an object managed by
+// a resource no longer exists on the platform. Read operations check for it
+// with hasNotFound in order to remove the resource from the state instead of
+// failing.
+type notFoundDiagnostic struct {
+	diag.ErrorDiagnostic
+}
+
+// newNotFoundDiagnostic returns a notFoundDiagnostic for the object of the
+// given kind (e.g. "instance") and UUID.
+func newNotFoundDiagnostic(kind, uuid string) diag.Diagnostic {
+	return notFoundDiagnostic{
+		ErrorDiagnostic: diag.NewErrorDiagnostic(
+			"Resource Not Found",
+			"The "+kind+" "+uuid+" no longer exists.",
+		),
+	}
+}
+
+// hasNotFound reports whether diags contain a notFoundDiagnostic.
+func hasNotFound(diags diag.Diagnostics) bool {
+	for _, d := range diags {
+		if _, ok := d.(notFoundDiagnostic); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+// Copyright (c) Unikraft GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeAPIError mimics the responses returned as errors by the platform client.
+type fakeAPIError struct {
+	body string
+}
+
+func (e *fakeAPIError) Error() string   { return "API error" }
+func (e *fakeAPIError) RawBody() []byte { return []byte(e.body) }
+
+// MarshalJSON returns the body of the response, like the fields a response
+// of the platform client is decoded into.
+func (e *fakeAPIError) MarshalJSON() ([]byte, error) { return []byte(e.body), nil }
+
+func TestIsNotFound(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"plain error", errors.New("404 not found"), false},
+		{"status code", &fakeAPIError{`{"status":"error","errors":[{"status":404}]}`}, true},
+		{"item error code", &fakeAPIError{`{"status":"error","data":{"instances":[{"status":"error","error":8}]}}`}, true},
+		{"other item error code", &fakeAPIError{`{"status":"error","data":{"instances":[{"status":"error","error":11}]}}`}, false},
+		{"wrapped", fmt.Errorf("performing the request: %w", &fakeAPIError{`{"errors":[{"status":404}]}`}), true},
+		{"invalid body", &fakeAPIError{`<html>`}, false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, isNotFound(tt.err), tt.name)
+	}
+}
+
+func TestHasNotFound(t *testing.T) {
+	var diags diag.Diagnostics
+	diags.AddError("Client Error", "boom")
+	assert.False(t, hasNotFound(diags))
+
+	diags.Append(newNotFoundDiagnostic("instance", "uuid"))
+	assert.True(t, hasNotFound(diags))
+	assert.True(t, diags.HasError())
+}
@@ -5,10 +5,18 @@ package resource
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -16,11 +24,14 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/unikraft-cloud/terraform-provider-unikraft-cloud/internal/provider/clients"
 	models "github.com/unikraft-cloud/terraform-provider-unikraft-cloud/internal/provider/model"
 
 	"unikraft.com/cloud/sdk/platform"
@@ -32,33 +43,72 @@ func NewInstanceResource() resource.Resource {
 
 // InstanceResource defines the resource implementation.
 type InstanceResource struct {
-	client platform.Client
+	clients *clients.Pool
 }
 
 // Ensure InstanceResource satisfies various resource interfaces.
 var (
-	_ resource.Resource                = &InstanceResource{}
-	_ resource.ResourceWithImportState = &InstanceResource{}
+	_ resource.Resource                 = &InstanceResource{}
+	_ resource.ResourceWithImportState  = &InstanceResource{}
+	_ resource.ResourceWithModifyPlan   = &InstanceResource{}
+	_ resource.ResourceWithUpgradeState = &InstanceResource{}
 )
 
+// Accepted values of the desired_state attribute.
+const (
+	desiredStateRunning = string(platform.InstanceStateRunning)
+	desiredStateStopped = string(platform.InstanceStateStopped)
+	desiredStateStandby = string(platform.InstanceStateStandby)
+)
+
+// Accepted values of the restart_policy attribute. They differ from the values
+// of the API, which uses underscores instead of dashes.
+const (
+	restartPolicyNever     = string(platform.InstanceRestartPolicyNever)
+	restartPolicyAlways    = string(platform.InstanceRestartPolicyAlways)
+	restartPolicyOnFailure = "on-failure"
+)
+
+// uuidPattern matches the textual representation of a UUID.
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// instanceDrainTimeout is the time given to an instance which is taken out of
+// its service group to complete in-flight requests before it is stopped.
+const instanceDrainTimeout = 30 * time.Second
+
 // InstanceResourceModel describes the resource data model.
 type InstanceResourceModel struct {
-	Image     types.String `tfsdk:"image"`
-	Args      types.List   `tfsdk:"args"`
-	MemoryMB  types.Int64  `tfsdk:"memory_mb"`
-	Autostart types.Bool   `tfsdk:"autostart"`
-
-	UUID              types.String        `tfsdk:"uuid"`
-	Name              types.String        `tfsdk:"name"`
-	FQDN              types.String        `tfsdk:"fqdn"`
-	PrivateIP         types.String        `tfsdk:"private_ip"`
-	PrivateFQDN       types.String        `tfsdk:"private_fqdn"`
-	State             types.String        `tfsdk:"state"`
-	CreatedAt         types.String        `tfsdk:"created_at"`
-	Env               types.Map           `tfsdk:"env"`
-	ServiceGroup      *models.SvcGrpModel `tfsdk:"service_group"`
-	NetworkInterfaces types.List          `tfsdk:"network_interfaces"`
-	BootTimeUS        types.Int64         `tfsdk:"boot_time_us"`
+	Metro             types.String `tfsdk:"metro"`
+	Image             types.String `tfsdk:"image"`
+	ImageDigest       types.String `tfsdk:"image_digest"`
+	TrackImageUpdates types.Bool   `tfsdk:"track_image_updates"`
+	Args              types.List   `tfsdk:"args"`
+	MemoryMB          types.Int64  `tfsdk:"memory_mb"`
+	VCPUs             types.Int64  `tfsdk:"vcpus"`
+	Autostart         types.Bool   `tfsdk:"autostart"`
+
+	DesiredState types.String `tfsdk:"desired_state"`
+
+	UUID              types.String             `tfsdk:"uuid"`
+	Name              types.String             `tfsdk:"name"`
+	FQDN              types.String             `tfsdk:"fqdn"`
+	PrivateIP         types.String             `tfsdk:"private_ip"`
+	PrivateFQDN       types.String             `tfsdk:"private_fqdn"`
+	State             types.String             `tfsdk:"state"`
+	CreatedAt         types.String             `tfsdk:"created_at"`
+	Env               types.Map                `tfsdk:"env"`
+	SecretEnv         types.Map                `tfsdk:"secret_env"`
+	ServiceGroupUUID  types.String             `tfsdk:"service_group_uuid"`
+	ServiceGroup      *models.SvcGrpModel      `tfsdk:"service_group"`
+	NetworkInterfaces types.List               `tfsdk:"network_interfaces"`
+	Volumes           types.List               `tfsdk:"volumes"`
+	ScaleToZero       *models.ScaleToZeroModel `tfsdk:"scale_to_zero"`
+	BootTimeUS        types.Int64              `tfsdk:"boot_time_us"`
+	RestartPolicy     types.String             `tfsdk:"restart_policy"`
+	ExitCode          types.Int64              `tfsdk:"exit_code"`
+	StopReason        types.String             `tfsdk:"stop_reason"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 // Metadata implements resource.Resource.
@@ -71,39 +121,97 @@ func (r *InstanceResource) Schema(ctx context.Context, req resource.SchemaReques
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Allows the creation of Unikraft Cloud instances.",
+		// Version 1 changed the certificate of domains from a map to a
+		// single object.
+		Version: 1,
 
 		Attributes: map[string]schema.Attribute{
+			"metro": metroAttribute(),
 			"image": schema.StringAttribute{
 				Required: true,
+				MarkdownDescription: "Image to run. When the instance is part of a service group given by " +
+					"`service_group_uuid`, and has neither volumes nor a configured `name`, changing the image rolls out a new instance " +
+					"in the same service group and deletes the old one once the new one is ready, so that " +
+					"the endpoint of the service group keeps serving. Otherwise, the instance is replaced.",
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.RequiresReplaceIf(
+						func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+							var sgUUID, name types.String
+							var volumes types.List
+							resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("service_group_uuid"), &sgUUID)...)
+							resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("volumes"), &volumes)...)
+							resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("name"), &name)...)
+							resp.RequiresReplace = !canRollout(sgUUID, volumes, name)
+						},
+						"Changing the image requires a replacement, unless the instance can be rolled out within its service group.",
+						"Changing the image requires a replacement, unless the instance can be rolled out within its service group.",
+					),
+				},
+			},
+			"image_digest": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Digest of the image the instance runs, which `image` was resolved to when the instance was created.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"track_image_updates": schema.BoolAttribute{
+				Optional: true,
+				MarkdownDescription: "Whether to check at plan time if the tag of `image` now refers to a different " +
+					"digest than `image_digest`. If it does, the plan shows the new digest and deploys it the same " +
+					"way as a change of `image`.",
+			},
 			"args": schema.ListAttribute{
 				ElementType: types.StringType,
 				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []planmodifier.List{
-					listplanmodifier.RequiresReplaceIfConfigured(),
 					listplanmodifier.UseStateForUnknown(),
 				},
 			},
 			"memory_mb": schema.Int64Attribute{
-				Optional: true,
-				Computed: true,
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Amount of memory of the instance in megabytes. Must lie within the limits of the account's quota.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"vcpus": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Number of virtual CPUs of the instance. Must lie within the limits of the account's quota.",
 				Validators: []validator.Int64{
-					int64validator.Between(16, 256),
+					int64validator.Between(1, math.MaxInt32),
 				},
 				PlanModifiers: []planmodifier.Int64{
-					int64planmodifier.RequiresReplaceIfConfigured(),
 					int64planmodifier.UseStateForUnknown(),
 				},
 			},
 			"autostart": schema.BoolAttribute{
 				Optional: true,
+				MarkdownDescription: "Whether to start the instance as soon as it is created. " +
+					"The platform cannot change this setting of an existing instance, so changing it replaces the instance.",
 				PlanModifiers: []planmodifier.Bool{
-					boolplanmodifier.RequiresReplaceIfConfigured(),
-					boolplanmodifier.UseStateForUnknown(),
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"desired_state": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Run state the instance should be kept in (`running`, `stopped` or `standby`). " +
+					"The instance is started or stopped whenever its actual state drifts from this value. " +
+					"Instances in `standby` are put to sleep by scale-to-zero while idle and woken up by " +
+					"incoming traffic, so `running` and `standby` are both satisfied by either state.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						desiredStateRunning,
+						desiredStateStopped,
+						desiredStateStandby,
+					),
+					stringvalidator.ConflictsWith(path.MatchRoot("autostart")),
 				},
 			},
 			"uuid": schema.StringAttribute{
@@ -114,105 +222,97 @@ func (r *InstanceResource) Schema(ctx context.Context, req resource.SchemaReques
 				},
 			},
 			"name": schema.StringAttribute{
-				Computed: true,
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Name of the instance. If not specified, a random name is generated. Changing the name replaces the instance.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"fqdn": schema.StringAttribute{
 				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"private_ip": schema.StringAttribute{
 				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"private_fqdn": schema.StringAttribute{
 				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"state": schema.StringAttribute{
 				Computed: true,
 			},
 			"created_at": schema.StringAttribute{
 				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"env": schema.MapAttribute{
 				ElementType: types.StringType,
-				Computed:    true,
+				Optional:    true,
+				MarkdownDescription: "Environment variables of the instance. Variables defined by the image are " +
+					"not reported. Removing the attribute clears the variables set through it.",
+			},
+			"secret_env": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Environment variables of the instance whose values are sensitive. Keys must not overlap with `env`.",
+			},
+			"service_group_uuid": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "UUID of an existing service group (see `ukc_service_group`) to add the instance to.",
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("service_group")),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"service_group": schema.SingleNestedAttribute{
-				Required: true,
+				Optional:            true,
+				MarkdownDescription: "Service group created for and deleted with the instance.",
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplaceIf(
+						func(ctx context.Context, req planmodifier.ObjectRequest, resp *objectplanmodifier.RequiresReplaceIfFuncResponse) {
+							resp.RequiresReplace = req.StateValue.IsNull() != req.PlanValue.IsNull()
+						},
+						"Adding or removing the service group of an instance requires a replacement.",
+						"Adding or removing the service group of an instance requires a replacement.",
+					),
+				},
 				Attributes: map[string]schema.Attribute{
 					"uuid": schema.StringAttribute{
 						Computed: true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
 					},
 					"name": schema.StringAttribute{
 						Computed: true,
-					},
-					"services": schema.ListNestedAttribute{
-						Required: true,
-						NestedObject: schema.NestedAttributeObject{
-							Attributes: map[string]schema.Attribute{
-								"port": schema.Int64Attribute{
-									Required: true,
-									Validators: []validator.Int64{
-										int64validator.Between(1, math.MaxUint16),
-									},
-									PlanModifiers: []planmodifier.Int64{
-										int64planmodifier.RequiresReplace(),
-									},
-								},
-								"destination_port": schema.Int64Attribute{
-									Optional: true,
-									Computed: true,
-									Validators: []validator.Int64{
-										int64validator.Between(1, math.MaxUint16),
-									},
-									PlanModifiers: []planmodifier.Int64{
-										int64planmodifier.RequiresReplaceIfConfigured(),
-										int64planmodifier.UseStateForUnknown(),
-									},
-								},
-								"handlers": schema.SetAttribute{
-									ElementType: types.StringType,
-									Optional:    true,
-									Computed:    true,
-									PlanModifiers: []planmodifier.Set{
-										setplanmodifier.RequiresReplaceIfConfigured(),
-										setplanmodifier.UseStateForUnknown(),
-									},
-								},
-							},
-						},
-					},
-					"domains": schema.ListNestedAttribute{
-						Optional: true,
-						NestedObject: schema.NestedAttributeObject{
-							Attributes: map[string]schema.Attribute{
-								"name": schema.StringAttribute{
-									Required: true,
-								},
-								"fqdn": schema.StringAttribute{
-									Computed: true,
-								},
-								"certificate": schema.MapNestedAttribute{
-									Optional: true,
-									NestedObject: schema.NestedAttributeObject{
-										Attributes: map[string]schema.Attribute{
-											"uuid": schema.StringAttribute{
-												Computed: true,
-											},
-											"name": schema.StringAttribute{
-												Computed: true,
-											},
-											"state": schema.StringAttribute{
-												Computed: true,
-											},
-										},
-									},
-								},
-							},
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
 						},
 					},
+					"services": servicesAttribute(),
+					"domains":  domainsAttribute(),
 				},
 			},
 			"network_interfaces": schema.ListNestedAttribute{
 				Computed: true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"uuid": schema.StringAttribute{
@@ -230,9 +330,126 @@ func (r *InstanceResource) Schema(ctx context.Context, req resource.SchemaReques
 					},
 				},
 			},
+			"volumes": schema.ListNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "Existing volumes to mount into the instance.",
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"uuid": schema.StringAttribute{
+							Optional:            true,
+							Computed:            true,
+							MarkdownDescription: "UUID of the volume. Exactly one of `uuid` or `name` must be set.",
+							Validators: []validator.String{
+								stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("name")),
+							},
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.UseStateForUnknown(),
+							},
+						},
+						"name": schema.StringAttribute{
+							Optional:            true,
+							Computed:            true,
+							MarkdownDescription: "Name of the volume. Exactly one of `uuid` or `name` must be set.",
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.UseStateForUnknown(),
+							},
+						},
+						"at": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Path at which the volume is mounted in the instance.",
+						},
+						"read_only": schema.BoolAttribute{
+							Optional:            true,
+							Computed:            true,
+							MarkdownDescription: "Whether the volume is mounted read-only. Defaults to `false`.",
+							PlanModifiers: []planmodifier.Bool{
+								boolplanmodifier.UseStateForUnknown(),
+							},
+						},
+					},
+				},
+			},
+			"scale_to_zero": schema.SingleNestedAttribute{
+				Optional: true,
+				MarkdownDescription: "Scale-to-zero configuration of the instance. Instances with scale-to-zero " +
+					"enabled are put into standby while they receive no traffic, and woken up by incoming " +
+					"requests. Removing this attribute turns scale-to-zero off.",
+				Attributes: map[string]schema.Attribute{
+					"policy": schema.StringAttribute{
+						Optional:            true,
+						Computed:            true,
+						MarkdownDescription: "Policy used to scale the instance to zero (`on`, `off` or `idle`).",
+						Validators: []validator.String{
+							stringvalidator.OneOf(
+								string(platform.InstanceScaleToZeroPolicyOn),
+								string(platform.InstanceScaleToZeroPolicyOff),
+								string(platform.InstanceScaleToZeroPolicyIdle),
+							),
+						},
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
+					},
+					"stateful": schema.BoolAttribute{
+						Optional:            true,
+						Computed:            true,
+						MarkdownDescription: "Whether the instance retains its state (e.g. memory contents) while scaled to zero.",
+						PlanModifiers: []planmodifier.Bool{
+							boolplanmodifier.UseStateForUnknown(),
+						},
+					},
+					"cooldown_time_ms": schema.Int64Attribute{
+						Optional:            true,
+						Computed:            true,
+						MarkdownDescription: "Time in milliseconds without traffic after which the instance is scaled to zero.",
+						Validators: []validator.Int64{
+							int64validator.Between(0, math.MaxInt32),
+						},
+						PlanModifiers: []planmodifier.Int64{
+							int64planmodifier.UseStateForUnknown(),
+						},
+					},
+				},
+			},
 			"boot_time_us": schema.Int64Attribute{
 				Computed: true,
 			},
+			"restart_policy": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				MarkdownDescription: "What the platform does when the application of the instance exits (`never`, " +
+					"`always` or `on-failure`). Changing the policy replaces the instance.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						restartPolicyNever,
+						restartPolicyAlways,
+						restartPolicyOnFailure,
+					),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"exit_code": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Exit code of the application the last time the instance stopped.",
+			},
+			"stop_reason": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Who or what stopped the instance the last time it stopped, and why.",
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
@@ -244,16 +461,16 @@ func (r *InstanceResource) Configure(ctx context.Context, req resource.Configure
 		return
 	}
 
-	client, ok := req.ProviderData.(platform.Client)
+	pool, ok := req.ProviderData.(*clients.Pool)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected platform.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *clients.Pool, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.clients = pool
 }
 
 // Create implements resource.Resource.
@@ -266,187 +483,170 @@ func (r *InstanceResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	in := platform.CreateInstanceRequest{
-		Image: data.Image.ValueString(),
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	// New SDK properly handles optional fields with pointers
-	if !data.MemoryMB.IsUnknown() && !data.MemoryMB.IsNull() {
-		memoryMB := data.MemoryMB.ValueInt64()
-		in.MemoryMb = &memoryMB
-	}
+	client := r.clients.Client(&data.Metro)
 
-	if !data.Autostart.IsUnknown() && !data.Autostart.IsNull() {
-		autostart := data.Autostart.ValueBool()
-		in.Autostart = &autostart
+	in, diags := instanceCreateRequest(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	if !data.Args.IsNull() && !data.Args.IsUnknown() {
-		argVals := make([]types.String, 0, len(data.Args.Elements()))
-		resp.Diagnostics.Append(data.Args.ElementsAs(ctx, &argVals, false)...)
-		for _, v := range argVals {
-			in.Args = append(in.Args, v.ValueString())
-		}
+	uuid, diags := createInstance(ctx, client, in, createTimeout, instanceTargetStates(&data)...)
+	resp.Diagnostics.Append(diags...)
+	if uuid == "" {
+		return
 	}
-
-	if data.ServiceGroup != nil && len(data.ServiceGroup.Services) > 0 {
-		sgServices := make([]platform.Service, len(data.ServiceGroup.Services))
-		for i, svc := range data.ServiceGroup.Services {
-			port := uint32(svc.Port.ValueInt64())
-			sgServices[i].Port = port
-
-			// New SDK properly handles optional destination port with pointer
-			if !svc.DestinationPort.IsUnknown() && !svc.DestinationPort.IsNull() {
-				destPort := uint32(svc.DestinationPort.ValueInt64())
-				sgServices[i].DestinationPort = &destPort
-			}
-
-			if !svc.Handlers.IsUnknown() {
-				handlVals := make([]types.String, 0, len(svc.Handlers.Elements()))
-				resp.Diagnostics.Append(svc.Handlers.ElementsAs(ctx, &handlVals, false)...)
-				for _, v := range handlVals {
-					sgServices[i].Handlers = append(sgServices[i].Handlers, platform.ServiceHandlers(v.ValueString()))
-				}
-			}
-		}
-		in.ServiceGroup = &platform.CreateInstanceRequestServiceGroup{
-			Services: sgServices,
-		}
+	data.UUID = types.StringValue(uuid)
+	if resp.Diagnostics.HasError() {
+		// The instance exists although it did not become ready. Save what is
+		// known about it, so that Terraform taints it rather than creating
+		// another one on the next apply.
+		resp.Diagnostics.Append(readInstanceState(ctx, client, &data)...)
+		resp.Diagnostics.Append(setPartialState(ctx, &resp.State, &data)...)
+		return
 	}
 
+	// Not all attributes are returned by CreateInstance
+	resp.Diagnostics.Append(readInstanceState(ctx, client, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	insResp, err := r.client.CreateInstance(ctx, in)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Client Error",
-			fmt.Sprintf("Failed to create instance, got error: %v", err),
-		)
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read implements resource.Resource.
+func (r *InstanceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data InstanceResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if insResp == nil || insResp.Data == nil || len(insResp.Data.Instances) == 0 {
-		resp.Diagnostics.AddError(
-			"Client Error",
-			"Empty response from create instance API",
-		)
+	client := r.clients.Client(&data.Metro)
+
+	diags := readInstanceState(ctx, client, &data)
+	if hasNotFound(diags) {
+		// The instance was deleted out-of-band, let Terraform recreate it.
+		resp.State.RemoveResource(ctx)
 		return
 	}
-	ins := insResp.Data.Instances[0]
-
-	if ins.Uuid == nil {
-		resp.Diagnostics.AddError(
-			"Client Error",
-			"Instance UUID not returned by API",
-		)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	data.UUID = types.StringValue(*ins.Uuid)
-	if ins.Name != nil {
-		data.Name = types.StringValue(*ins.Name)
-	}
-	if ins.ServiceGroup != nil && len(ins.ServiceGroup.Domains) > 0 && ins.ServiceGroup.Domains[0].Fqdn != nil {
-		data.FQDN = types.StringValue(*ins.ServiceGroup.Domains[0].Fqdn)
-	}
-	if ins.PrivateFqdn != nil {
-		data.PrivateFQDN = types.StringValue(*ins.PrivateFqdn)
+	// Surface a drift of the run state as a change of desired_state, so that
+	// the next plan proposes to start or stop the instance again.
+	if !data.DesiredState.IsNull() && !data.State.IsNull() {
+		actual := platform.InstanceState(data.State.ValueString())
+		if !instanceStateSatisfies(data.DesiredState.ValueString(), actual) {
+			data.DesiredState = types.StringValue(desiredStateOf(actual))
+		}
 	}
 
-	// Not all attributes are returned by CreateInstance
-	insFullResp, err := r.client.GetInstanceByUUID(ctx, *ins.Uuid, true)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Client Error",
-			fmt.Sprintf("Failed to get instance state, got error: %v", err),
-		)
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update implements resource.Resource.
+func (r *InstanceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan InstanceResourceModel
+	var state InstanceResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if insFullResp == nil || insFullResp.Data == nil || len(insFullResp.Data.Instances) == 0 {
-		resp.Diagnostics.AddError(
-			"Client Error",
-			"Empty response from get instance API",
-		)
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-	insFull := insFullResp.Data.Instances[0]
 
-	var diags diag.Diagnostics
+	client := r.clients.Client(&plan.Metro)
 
-	// NOTE(antoineco): although the Image attribute may be transformed by
-	// Unikraft Cloud (e.g. replace the tag with a digest), we must not update the
-	// value read from the schema, otherwise Terraform fails to apply with the
-	// following error:
-	//
-	//   Error: Provider produced inconsistent result after apply
-	//   When applying changes to unikraft-cloud_instance.xyz, provider produced an unexpected new value: .image:
-	//     was cty.StringVal("myimage:latest"), but now cty.StringVal("myimage@sha256:18a381f0062...").
-	//
-	if insFull.State != nil {
-		data.State = types.StringValue(string(*insFull.State))
-	}
-	if insFull.CreatedAt != nil {
-		data.CreatedAt = types.StringValue(insFull.CreatedAt.Format("2006-01-02T15:04:05.999999999Z07:00"))
-	}
-	if insFull.MemoryMb != nil {
-		data.MemoryMB = types.Int64Value(int64(*insFull.MemoryMb))
-	}
-	if insFull.BootTimeUs != nil {
-		data.BootTimeUS = types.Int64Value(int64(*insFull.BootTimeUs))
-	}
+	uuid := state.UUID.ValueString()
 
-	if data.Args.IsNull() || data.Args.IsUnknown() {
-		if insFull.Args != nil {
-			data.Args, diags = types.ListValueFrom(ctx, types.StringType, insFull.Args)
-			resp.Diagnostics.Append(diags...)
+	if !plan.Image.Equal(state.Image) || !plan.ImageDigest.Equal(state.ImageDigest) {
+		// The new instance is created with all planned properties, so no
+		// further updates need to be applied to it.
+		var diags diag.Diagnostics
+		uuid, diags = rolloutInstance(ctx, client, &plan, &state, updateTimeout)
+		resp.Diagnostics.Append(diags...)
+		if uuid == "" {
+			return
 		}
-	}
-
-	if insFull.Env != nil {
-		data.Env, diags = types.MapValueFrom(ctx, types.StringType, insFull.Env)
+		if resp.Diagnostics.HasError() {
+			// The replacement is in place although the old instance could
+			// not be retired. Save the replacement, so that Terraform does
+			// not lose track of it.
+			data := plan
+			data.UUID = types.StringValue(uuid)
+			resp.Diagnostics.Append(readInstanceState(ctx, client, &data)...)
+			resp.Diagnostics.Append(setPartialState(ctx, &resp.State, &data)...)
+			return
+		}
+	} else {
+		updates, diags := instanceUpdates(ctx, &plan, &state)
 		resp.Diagnostics.Append(diags...)
-	}
+		if resp.Diagnostics.HasError() {
+			return
+		}
 
-	if data.ServiceGroup == nil {
-		data.ServiceGroup = &models.SvcGrpModel{}
-	}
+		// When a desired run state is set, it is enforced below, so there is no
+		// point in restarting the instance after applying property updates.
+		hasDesiredState := !plan.DesiredState.IsNull() && !plan.DesiredState.IsUnknown()
 
-	if insFull.ServiceGroup != nil {
-		if insFull.ServiceGroup.Uuid != nil {
-			data.ServiceGroup.UUID = types.StringValue(*insFull.ServiceGroup.Uuid)
+		if len(updates) > 0 {
+			resp.Diagnostics.Append(applyInstanceUpdates(ctx, client, uuid, updates, !hasDesiredState, updateTimeout)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
 		}
-		if insFull.ServiceGroup.Name != nil {
-			data.ServiceGroup.Name = types.StringValue(*insFull.ServiceGroup.Name)
+
+		if hasDesiredState {
+			resp.Diagnostics.Append(applyDesiredState(ctx, client, uuid, plan.DesiredState.ValueString(), updateTimeout)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
 		}
 	}
 
-	if insFull.NetworkInterfaces != nil {
-		netwIfaces := make([]models.NetwIfaceModel, len(insFull.NetworkInterfaces))
-		for i, net := range insFull.NetworkInterfaces {
-			if net.Uuid != nil {
-				netwIfaces[i].UUID = types.StringValue(*net.Uuid)
-				netwIfaces[i].Name = types.StringValue(*net.Uuid) // No name in the response
-			}
-			if net.PrivateIp != nil {
-				netwIfaces[i].PrivateIP = types.StringValue(*net.PrivateIp)
-			}
-			if net.Mac != nil {
-				netwIfaces[i].MAC = types.StringValue(*net.Mac)
-			}
+	// Services and domains are properties of the instance's service group,
+	// which can be updated without interrupting the instance.
+	if plan.ServiceGroup != nil && state.ServiceGroup != nil {
+		resp.Diagnostics.Append(updateServiceGroup(ctx, client, state.ServiceGroup.UUID.ValueString(), plan.ServiceGroup, state.ServiceGroup)...)
+		if resp.Diagnostics.HasError() {
+			return
 		}
-		data.NetworkInterfaces, diags = types.ListValueFrom(ctx, models.NetwIfaceModelType, netwIfaces)
-		resp.Diagnostics.Append(diags...)
 	}
 
-	// Save data into Terraform state
+	// Re-read full state after update
+	data := plan
+	data.UUID = types.StringValue(uuid)
+	resp.Diagnostics.Append(readInstanceState(ctx, client, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-// Read implements resource.Resource.
-func (r *InstanceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+// Delete implements resource.Resource.
+func (r *InstanceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var data InstanceResourceModel
 
 	// Read Terraform prior state data into the model
@@ -455,26 +655,362 @@ func (r *InstanceResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
-	insResp, err := r.client.GetInstanceByUUID(ctx, data.UUID.ValueString(), true)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Client Error",
-			fmt.Sprintf("Failed to get instance state, got error: %v", err),
-		)
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if insResp == nil || insResp.Data == nil || len(insResp.Data.Instances) == 0 {
-		resp.Diagnostics.AddError(
-			"Client Error",
+	client := r.clients.Client(&data.Metro)
+
+	// Instances of a service group which outlives them are drained first, so
+	// that a replacement created before them takes over their traffic.
+	if !data.ServiceGroupUUID.IsNull() {
+		resp.Diagnostics.Append(retireInstance(ctx, client, data.UUID.ValueString(), deleteTimeout)...)
+		return
+	}
+
+	_, err := client.DeleteInstanceByUUID(ctx, data.UUID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Failed to delete instance, got error: %v", err),
+		)
+		return
+	}
+
+	if err := waitInstanceDeleted(ctx, client, data.UUID.ValueString(), deleteTimeout); err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Instance %s was not deleted, got error: %v", data.UUID.ValueString(), err),
+		)
+		return
+	}
+}
+
+// UpgradeState implements resource.ResourceWithUpgradeState.
+func (r *InstanceResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {StateUpgrader: upgradeInstanceStateV0},
+	}
+}
+
+// upgradeInstanceStateV0 upgrades the state of an instance from version 0, in
+// which the certificate of a domain was a map of certificates. The prior
+// state is rewritten as JSON, as its schema only differs in this attribute.
+func upgradeInstanceStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	if req.RawState == nil {
+		return
+	}
+
+	var raw map[string]any
+	if err := json.Unmarshal(req.RawState.JSON, &raw); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Upgrade Resource State",
+			fmt.Sprintf("Failed to decode the prior state of the instance, got error: %v", err),
+		)
+		return
+	}
+
+	if sg, ok := raw["service_group"].(map[string]any); ok {
+		domains, _ := sg["domains"].([]any)
+		for _, d := range domains {
+			if dom, ok := d.(map[string]any); ok {
+				dom["certificate"] = upgradeDomainCertificateV0(dom["certificate"])
+			}
+		}
+	}
+
+	b, err := json.Marshal(raw)
+	if err == nil {
+		rawState := tfprotov6.RawState{JSON: b}
+		resp.State.Raw, err = rawState.UnmarshalWithOpts(resp.State.Schema.Type().TerraformType(ctx), tfprotov6.UnmarshalOpts{
+			ValueFromJSONOpts: tftypes.ValueFromJSONOpts{IgnoreUndefinedAttributes: true},
+		})
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Upgrade Resource State",
+			fmt.Sprintf("Failed to upgrade the prior state of the instance, got error: %v", err),
+		)
+	}
+}
+
+// upgradeDomainCertificateV0 converts the certificate of a domain from the map
+// of certificates of version 0 to the certificate it holds, or nil if it does
+// not hold exactly one.
+func upgradeDomainCertificateV0(v any) any {
+	certs, ok := v.(map[string]any)
+	if !ok || len(certs) != 1 {
+		return nil
+	}
+	for _, crt := range certs {
+		if crt, ok := crt.(map[string]any); ok {
+			return crt
+		}
+	}
+	return nil
+}
+
+// ImportState implements resource.ResourceWithImportState.
+//
+// Instances can be imported by UUID or by name, optionally prefixed by the
+// metro they are located in. Names are resolved to the UUID of the instance
+// they belong to.
+func (r *InstanceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	metro, parts, ok := parseImportID(req.ID, 1)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected an import ID of the form [<metro>/]<uuid> or [<metro>/]<name>, got: %q", req.ID),
+		)
+		return
+	}
+	id := parts[0]
+
+	if metro != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("metro"), metro)...)
+	}
+
+	if uuidPattern.MatchString(id) {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("uuid"), id)...)
+		return
+	}
+
+	insResp, err := r.clients.Get(metro).GetInstances(ctx, []platform.NameOrUUID{{Name: &id}}, false)
+	if isNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Cannot Import Non-Existent Instance",
+			fmt.Sprintf("No instance named %q exists.", id),
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Failed to get instance %q, got error: %v", id, err),
+		)
+		return
+	}
+
+	if insResp == nil || insResp.Data == nil || len(insResp.Data.Instances) == 0 {
+		resp.Diagnostics.AddError(
+			"Client Error",
 			"Empty response from get instance API",
 		)
 		return
 	}
 	ins := insResp.Data.Instances[0]
 
+	if isNotFoundCode(ins.Error) {
+		resp.Diagnostics.AddError(
+			"Cannot Import Non-Existent Instance",
+			fmt.Sprintf("No instance named %q exists.", id),
+		)
+		return
+	}
+	if ins.Uuid == nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			"Instance UUID not returned by API",
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("uuid"), *ins.Uuid)...)
+}
+
+// ModifyPlan implements resource.ResourceWithModifyPlan.
+func (r *InstanceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destruction.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	modifyPlanMetro(ctx, r.clients, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The provider may not be configured yet, e.g. during validation.
+	var client platform.Client
+	if r.clients != nil {
+		var metro types.String
+		resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("metro"), &metro)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		client = r.clients.Get(metro.ValueString())
+	}
+
+	resp.Diagnostics.Append(checkQuota(ctx, client, req)...)
+	if resp.Diagnostics.HasError() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var planImage, stateImage, stateDigest types.String
+	var trackUpdates types.Bool
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("image"), &planImage)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("track_image_updates"), &trackUpdates)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("image"), &stateImage)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("image_digest"), &stateDigest)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if planImage.Equal(stateImage) {
+		if !trackUpdates.ValueBool() {
+			return
+		}
+
+		digest, diags := resolveImageDigest(ctx, client, planImage.ValueString())
+		resp.Diagnostics.Append(diags...)
+		if digest == "" || digest == stateDigest.ValueString() {
+			return
+		}
+
+		// The tag of the image now refers to another image, which is deployed
+		// the same way as a change of the image itself.
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("image_digest"), digest)...)
+
+		var sgUUID, name types.String
+		var volumes types.List
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("service_group_uuid"), &sgUUID)...)
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("volumes"), &volumes)...)
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("name"), &name)...)
+		if !canRollout(sgUUID, volumes, name) {
+			resp.RequiresReplace = append(resp.RequiresReplace, path.Root("image_digest"))
+			return
+		}
+	} else {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("image_digest"), types.StringUnknown())...)
+	}
+
+	// A new image is rolled out as a new instance, whose attributes are not
+	// known until it has been created.
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("uuid"), types.StringUnknown())...)
+	var name types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("name"), &name)...)
+	if name.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("name"), types.StringUnknown())...)
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("private_ip"), types.StringUnknown())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("private_fqdn"), types.StringUnknown())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("created_at"), types.StringUnknown())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("network_interfaces"), types.ListUnknown(models.NetwIfaceModelType))...)
+}
+
+// resolveImageDigest returns the digest of the image the given reference
+// currently refers to. References which are pinned to a digest, as well as
+// failures to resolve the reference, yield an empty digest.
+func resolveImageDigest(ctx context.Context, client platform.Client, image string) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if client == nil || imageDigest(image) != "" {
+		return "", diags
+	}
+
+	imgResp, err := client.GetImageByTag(ctx, image)
+	if err != nil {
+		diags.AddWarning(
+			"Client Error",
+			fmt.Sprintf("Unable to check image %s for updates, got error: %v", image, err),
+		)
+		return "", diags
+	}
+
+	if imgResp == nil || imgResp.Data == nil || imgResp.Data.Image == nil || imgResp.Data.Image.Digest == nil {
+		diags.AddWarning(
+			"Client Error",
+			fmt.Sprintf("Unable to check image %s for updates, got an empty response from get image API", image),
+		)
+		return "", diags
+	}
+
+	return *imgResp.Data.Image.Digest, diags
+}
+
+// checkQuota verifies that the planned size of the instance lies within the
+// limits of the account's quota. The quota is only fetched when the size of
+// the instance changes, so that plans without changes do not depend on it.
+func checkQuota(ctx context.Context, client platform.Client, req resource.ModifyPlanRequest) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// The provider may not be configured yet, e.g. during validation.
+	if client == nil {
+		return diags
+	}
+
+	var planMemory, planVCPUs types.Int64
+	diags.Append(req.Plan.GetAttribute(ctx, path.Root("memory_mb"), &planMemory)...)
+	diags.Append(req.Plan.GetAttribute(ctx, path.Root("vcpus"), &planVCPUs)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	stateMemory, stateVCPUs := types.Int64Null(), types.Int64Null()
+	if !req.State.Raw.IsNull() {
+		diags.Append(req.State.GetAttribute(ctx, path.Root("memory_mb"), &stateMemory)...)
+		diags.Append(req.State.GetAttribute(ctx, path.Root("vcpus"), &stateVCPUs)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	memoryChanged := !planMemory.IsNull() && !planMemory.IsUnknown() && !planMemory.Equal(stateMemory)
+	vcpusChanged := !planVCPUs.IsNull() && !planVCPUs.IsUnknown() && !planVCPUs.Equal(stateVCPUs)
+	if !memoryChanged && !vcpusChanged {
+		return diags
+	}
+
+	limits, err := getQuotaLimits(ctx, client)
+	if err != nil {
+		diags.AddWarning(
+			"Client Error",
+			fmt.Sprintf("Unable to verify the size of the instance against the quota of the account, got error: %v", err),
+		)
+		return diags
+	}
+
+	if memoryChanged {
+		diags.Append(checkQuotaLimit(path.Root("memory_mb"), planMemory, " MB", limits.MinMemoryMb, limits.MaxMemoryMb)...)
+	}
+	if vcpusChanged {
+		diags.Append(checkQuotaLimit(path.Root("vcpus"), planVCPUs, " vCPUs", limits.MinVcpus, limits.MaxVcpus)...)
+	}
+
+	return diags
+}
+
+// readInstanceState fetches the current instance state from the API and
+// populates computed fields in the model.
+func readInstanceState(ctx context.Context, client platform.Client, data *InstanceResourceModel) diag.Diagnostics {
 	var diags diag.Diagnostics
 
+	insResp, err := client.GetInstanceByUUID(ctx, data.UUID.ValueString(), true)
+	if isNotFound(err) {
+		diags.Append(newNotFoundDiagnostic("instance", data.UUID.ValueString()))
+		return diags
+	}
+	if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Failed to get instance state, got error: %v", err),
+		)
+		return diags
+	}
+
+	if insResp == nil || insResp.Data == nil || len(insResp.Data.Instances) == 0 {
+		diags.AddError(
+			"Client Error",
+			"Empty response from get instance API",
+		)
+		return diags
+	}
+	ins := insResp.Data.Instances[0]
+
+	var d diag.Diagnostics
+
 	// NOTE(antoineco): although the Image attribute may be transformed by
 	// Unikraft Cloud (e.g. replace the tag with a digest), we must not update the
 	// value read from the schema, otherwise Terraform fails to apply with the
@@ -485,15 +1021,23 @@ func (r *InstanceResource) Read(ctx context.Context, req resource.ReadRequest, r
 	//     was cty.StringVal("myimage:latest"), but now cty.StringVal("myimage@sha256:18a381f0062...").
 	//
 	// However, we must still ensure that the Image attribute is populated by
-	// "terraform import".
-	if data.Image.IsNull() && ins.Image != nil {
+	// "terraform import". The resolved digest is recorded in image_digest.
+	importing := data.Image.IsNull()
+	if importing && ins.Image != nil {
 		data.Image = types.StringValue(*ins.Image)
 	}
+	if ins.Image != nil && imageDigest(*ins.Image) != "" {
+		data.ImageDigest = types.StringValue(imageDigest(*ins.Image))
+	} else {
+		data.ImageDigest = types.StringNull()
+	}
 	if ins.Name != nil {
 		data.Name = types.StringValue(*ins.Name)
 	}
 	if ins.ServiceGroup != nil && len(ins.ServiceGroup.Domains) > 0 && ins.ServiceGroup.Domains[0].Fqdn != nil {
 		data.FQDN = types.StringValue(*ins.ServiceGroup.Domains[0].Fqdn)
+	} else {
+		data.FQDN = types.StringNull()
 	}
 	if ins.PrivateFqdn != nil {
 		data.PrivateFQDN = types.StringValue(*ins.PrivateFqdn)
@@ -507,89 +1051,951 @@ func (r *InstanceResource) Read(ctx context.Context, req resource.ReadRequest, r
 	if ins.MemoryMb != nil {
 		data.MemoryMB = types.Int64Value(int64(*ins.MemoryMb))
 	}
+	if ins.Vcpus != nil {
+		data.VCPUs = types.Int64Value(int64(*ins.Vcpus))
+	}
 	if ins.BootTimeUs != nil {
 		data.BootTimeUS = types.Int64Value(int64(*ins.BootTimeUs))
+	} else {
+		data.BootTimeUS = types.Int64Null()
+	}
+	if ins.RestartPolicy != nil {
+		data.RestartPolicy = types.StringValue(strings.ReplaceAll(string(*ins.RestartPolicy), "_", "-"))
+	}
+	if ins.ExitCode != nil {
+		data.ExitCode = types.Int64Value(int64(*ins.ExitCode))
+	} else {
+		data.ExitCode = types.Int64Null()
+	}
+	if ins.StopReason != nil {
+		data.StopReason = types.StringValue(describeStopCause(&ins))
+	} else {
+		data.StopReason = types.StringNull()
 	}
 
 	if data.Args.IsNull() || data.Args.IsUnknown() {
 		if ins.Args != nil {
-			data.Args, diags = types.ListValueFrom(ctx, types.StringType, ins.Args)
-			resp.Diagnostics.Append(diags...)
+			data.Args, d = types.ListValueFrom(ctx, types.StringType, ins.Args)
+			diags.Append(d...)
+		} else {
+			data.Args = types.ListNull(types.StringType)
 		}
 	}
 
-	if ins.Env != nil {
-		data.Env, diags = types.MapValueFrom(ctx, types.StringType, ins.Env)
-		resp.Diagnostics.Append(diags...)
+	diags.Append(readInstanceEnv(ctx, ins.Env, data)...)
+	diags.Append(readInstanceVolumes(ctx, ins.Volumes, data, importing)...)
+
+	// The scale-to-zero configuration is only tracked when it is configured,
+	// otherwise the defaults of the platform would show up as drift.
+	if data.ScaleToZero != nil || (importing && ins.ScaleToZero != nil) {
+		if data.ScaleToZero == nil {
+			data.ScaleToZero = &models.ScaleToZeroModel{}
+		}
+		readScaleToZero(ins.ScaleToZero, data.ScaleToZero)
 	}
 
-	if data.ServiceGroup == nil {
-		data.ServiceGroup = &models.SvcGrpModel{}
+	var sgUUID string
+	if ins.ServiceGroup != nil && ins.ServiceGroup.Uuid != nil {
+		sgUUID = *ins.ServiceGroup.Uuid
 	}
 
-	if ins.ServiceGroup != nil {
-		if ins.ServiceGroup.Uuid != nil {
-			data.ServiceGroup.UUID = types.StringValue(*ins.ServiceGroup.Uuid)
+	switch {
+	case !data.ServiceGroupUUID.IsNull():
+		// The instance was added to an existing service group, which is
+		// managed on its own.
+		if sgUUID != "" {
+			data.ServiceGroupUUID = types.StringValue(sgUUID)
+		} else {
+			data.ServiceGroupUUID = types.StringNull()
+		}
+
+	case data.ServiceGroup != nil || (importing && sgUUID != ""):
+		if data.ServiceGroup == nil {
+			data.ServiceGroup = &models.SvcGrpModel{}
+		}
+		if sgUUID == "" {
+			data.ServiceGroup.UUID = types.StringNull()
+			data.ServiceGroup.Name = types.StringNull()
+			break
 		}
-		if ins.ServiceGroup.Name != nil {
-			data.ServiceGroup.Name = types.StringValue(*ins.ServiceGroup.Name)
+
+		d = readServiceGroup(ctx, client, sgUUID, data.ServiceGroup)
+		if hasNotFound(d) {
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Service group %s of the instance no longer exists", sgUUID),
+			)
+		} else {
+			diags.Append(d...)
 		}
 	}
 
+	data.PrivateIP = types.StringNull()
 	if ins.NetworkInterfaces != nil {
 		netwIfaces := make([]models.NetwIfaceModel, len(ins.NetworkInterfaces))
 		for i, net := range ins.NetworkInterfaces {
 			if net.Uuid != nil {
 				netwIfaces[i].UUID = types.StringValue(*net.Uuid)
-			}
-			if net.Uuid != nil {
 				netwIfaces[i].Name = types.StringValue(*net.Uuid) // No name in the response
 			}
 			if net.PrivateIp != nil {
 				netwIfaces[i].PrivateIP = types.StringValue(*net.PrivateIp)
+				if i == 0 {
+					data.PrivateIP = netwIfaces[i].PrivateIP
+				}
 			}
 			if net.Mac != nil {
 				netwIfaces[i].MAC = types.StringValue(*net.Mac)
 			}
 		}
-		data.NetworkInterfaces, diags = types.ListValueFrom(ctx, models.NetwIfaceModelType, netwIfaces)
-		resp.Diagnostics.Append(diags...)
+		data.NetworkInterfaces, d = types.ListValueFrom(ctx, models.NetwIfaceModelType, netwIfaces)
+		diags.Append(d...)
+	} else {
+		data.NetworkInterfaces = types.ListNull(models.NetwIfaceModelType)
 	}
 
-	// Save updated data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	return diags
 }
 
-// Update implements resource.Resource.
-func (r *InstanceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError(
-		"Unsupported",
-		"This resource does not support updates. Configuration changes were expected to have triggered a replacement "+
-			"of the resource. Please report this issue to the provider developers.",
-	)
+// liveInstanceProps are the properties of an instance which the platform
+// applies without stopping the instance.
+var liveInstanceProps = []platform.UpdateInstanceByUUIDRequestBodyProp{
+	platform.UpdateInstanceByUUIDRequestBodyPropScale_to_zero,
+	platform.UpdateInstanceByUUIDRequestBodyPropTags,
+	platform.UpdateInstanceByUUIDRequestBodyPropDelete_lock,
 }
 
-// Delete implements resource.Resource.
-func (r *InstanceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var data InstanceResourceModel
+// applyInstanceUpdates patches the properties of an existing instance.
+// Properties in liveInstanceProps are patched right away. The platform only
+// accepts changes of the other properties (image, args, env, memory and vCPUs)
+// on stopped instances, so an instance which is not already stopped is
+// stopped first, and started again once they have been applied if restart is
+// true.
+func applyInstanceUpdates(ctx context.Context, client platform.Client, uuid string, updates []platform.UpdateInstanceByUUIDRequestBody, restart bool, timeout time.Duration) diag.Diagnostics {
+	var diags diag.Diagnostics
 
-	// Read Terraform prior state data into the model
-	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
-	if resp.Diagnostics.HasError() {
-		return
+	var live, stopped []platform.UpdateInstanceByUUIDRequestBody
+	for _, u := range updates {
+		if slices.Contains(liveInstanceProps, u.Prop) {
+			live = append(live, u)
+		} else {
+			stopped = append(stopped, u)
+		}
+	}
+
+	diags.Append(patchInstance(ctx, client, uuid, live)...)
+	if diags.HasError() || len(stopped) == 0 {
+		return diags
 	}
 
-	_, err := r.client.DeleteInstanceByUUID(ctx, data.UUID.ValueString())
+	insResp, err := client.GetInstanceByUUID(ctx, uuid, false)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Failed to get instance state, got error: %v", err),
+		)
+		return diags
+	}
+
+	if insResp == nil || insResp.Data == nil || len(insResp.Data.Instances) == 0 {
+		diags.AddError(
+			"Client Error",
+			"Empty response from get instance API",
+		)
+		return diags
+	}
+	ins := insResp.Data.Instances[0]
+
+	wasStopped := ins.State != nil && *ins.State == platform.InstanceStateStopped
+
+	if !wasStopped {
+		if _, err := client.StopInstanceByUUID(ctx, uuid, false, 0); err != nil {
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Failed to stop instance for update, got error: %v", err),
+			)
+			return diags
+		}
+
+		if err := waitInstanceState(ctx, client, uuid, timeout, platform.InstanceStateStopped); err != nil {
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Failed to wait for instance to stop, got error: %v", err),
+			)
+			return diags
+		}
+	}
+
+	diags.Append(patchInstance(ctx, client, uuid, stopped)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	if !wasStopped && restart {
+		if _, err := client.StartInstanceByUUID(ctx, uuid); err != nil {
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Failed to start instance after update, got error: %v", err),
+			)
+			return diags
+		}
+
+		if err := waitInstanceState(ctx, client, uuid, timeout, instanceRunningStates...); err != nil {
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Instance %s did not become ready after update, got error: %v", uuid, err),
+			)
+			return diags
+		}
+	}
+
+	return diags
+}
+
+// patchInstance applies the given property updates to an existing instance
+// one after the other.
+func patchInstance(ctx context.Context, client platform.Client, uuid string, updates []platform.UpdateInstanceByUUIDRequestBody) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, u := range updates {
+		if _, err := client.UpdateInstanceByUUID(ctx, uuid, u); err != nil {
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Failed to update instance %s, got error: %v", u.Prop, err),
+			)
+			return diags
+		}
+	}
+
+	return diags
+}
+
+// applyDesiredState starts or stops an existing instance so that it reaches
+// the given desired run state.
+func applyDesiredState(ctx context.Context, client platform.Client, uuid string, desired string, timeout time.Duration) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	insResp, err := client.GetInstanceByUUID(ctx, uuid, false)
+	if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Failed to get instance state, got error: %v", err),
+		)
+		return diags
+	}
+
+	if insResp == nil || insResp.Data == nil || len(insResp.Data.Instances) == 0 {
+		diags.AddError(
+			"Client Error",
+			"Empty response from get instance API",
+		)
+		return diags
+	}
+	ins := insResp.Data.Instances[0]
+
+	if ins.State != nil && instanceStateSatisfies(desired, *ins.State) {
+		return diags
+	}
+
+	if desired == desiredStateStopped {
+		if _, err := client.StopInstanceByUUID(ctx, uuid, false, 0); err != nil {
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Failed to stop instance, got error: %v", err),
+			)
+			return diags
+		}
+	} else {
+		if _, err := client.StartInstanceByUUID(ctx, uuid); err != nil {
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Failed to start instance, got error: %v", err),
+			)
+			return diags
+		}
+	}
+
+	if err := waitInstanceState(ctx, client, uuid, timeout, desiredStateTargets(desired)...); err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Instance %s did not reach the %s state, got error: %v", uuid, desired, err),
+		)
+	}
+
+	return diags
+}
+
+// createInstance creates an instance and waits until it reaches one of the
+// given states. The UUID of the instance is returned as soon as it is known,
+// also when waiting fails, so that callers can clean up after it.
+func createInstance(ctx context.Context, client platform.Client, in platform.CreateInstanceRequest, timeout time.Duration, targets ...platform.InstanceState) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	insResp, err := client.CreateInstance(ctx, in)
+	if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Failed to create instance, got error: %v", err),
+		)
+		return "", diags
+	}
+
+	if insResp == nil || insResp.Data == nil || len(insResp.Data.Instances) == 0 {
+		diags.AddError(
+			"Client Error",
+			"Empty response from create instance API",
+		)
+		return "", diags
+	}
+	ins := insResp.Data.Instances[0]
+
+	if ins.Uuid == nil {
+		diags.AddError(
+			"Client Error",
+			"Instance UUID not returned by API",
+		)
+		return "", diags
+	}
+
+	if err := waitInstanceState(ctx, client, *ins.Uuid, timeout, targets...); err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Instance %s did not become ready, got error: %v", *ins.Uuid, err),
+		)
+	}
+
+	return *ins.Uuid, diags
+}
+
+// rolloutInstance replaces the instance described by state with a new one
+// created from plan, without interrupting the traffic of their service group.
+// The new instance joins the service group of the old one and must become
+// ready before the old instance is drained and deleted. If it does not, it is
+// deleted again and the old instance is left in place. The UUID of the new
+// instance is returned, even if the old instance could not be retired, and is
+// empty if the old instance is left in place.
+func rolloutInstance(ctx context.Context, client platform.Client, plan, state *InstanceResourceModel, timeout time.Duration) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	in, d := instanceCreateRequest(ctx, plan)
+	diags.Append(d...)
+	if diags.HasError() {
+		return "", diags
+	}
+	in.ServiceGroup = &platform.CreateInstanceRequestServiceGroup{
+		Uuid: state.ServiceGroupUUID.ValueStringPointer(),
+	}
+
+	uuid, d := createInstance(ctx, client, in, timeout, instanceTargetStates(plan)...)
+	if d.HasError() {
+		if uuid != "" {
+			if _, err := client.DeleteInstanceByUUID(ctx, uuid); err != nil {
+				d.AddWarning(
+					"Client Error",
+					fmt.Sprintf("Failed to delete instance %s after a failed rollout, got error: %v", uuid, err),
+				)
+			}
+		}
+		diags.Append(d...)
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("The instance %s was left in place because its replacement did not become ready.", state.UUID.ValueString()),
+		)
+		return "", diags
+	}
+	diags.Append(d...)
+
+	d = retireInstance(ctx, client, state.UUID.ValueString(), timeout)
+	if d.HasError() {
+		d.AddError(
+			"Client Error",
+			fmt.Sprintf("The instance %s was replaced by %s but could not be retired, and must be deleted manually.", state.UUID.ValueString(), uuid),
+		)
+	}
+	diags.Append(d...)
+
+	return uuid, diags
+}
+
+// retireInstance deletes an instance which is part of a service group. The
+// instance is drained first, so that the load balancer stops sending it new
+// connections and in-flight requests can complete.
+func retireInstance(ctx context.Context, client platform.Client, uuid string, timeout time.Duration) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	_, err := client.StopInstanceByUUID(ctx, uuid, false, int32(instanceDrainTimeout.Milliseconds()))
+	switch {
+	case isNotFound(err):
+		return diags
+	case err != nil:
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Failed to drain instance %s, got error: %v", uuid, err),
+		)
+		return diags
+	}
+
+	if err := waitInstanceState(ctx, client, uuid, timeout, platform.InstanceStateStopped); err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Failed to wait for instance %s to drain, got error: %v", uuid, err),
+		)
+		return diags
+	}
+
+	if _, err := client.DeleteInstanceByUUID(ctx, uuid); err != nil {
+		diags.AddError(
 			"Client Error",
 			fmt.Sprintf("Failed to delete instance, got error: %v", err),
 		)
-		return
+		return diags
 	}
+
+	if err := waitInstanceDeleted(ctx, client, uuid, timeout); err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Instance %s was not deleted, got error: %v", uuid, err),
+		)
+	}
+
+	return diags
 }
 
-// ImportState implements resource.ResourceWithImportState.
-func (r *InstanceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("uuid"), req, resp)
+// instanceRunningStates are the states in which an instance is considered up
+// and serving. Instances with scale-to-zero enabled may be put into standby as
+// soon as they become ready.
+var instanceRunningStates = []platform.InstanceState{
+	platform.InstanceStateRunning,
+	platform.InstanceStateStandby,
+}
+
+// desiredStateTargets returns the states an instance is awaited in after it
+// has been started or stopped to reach the given desired run state.
+func desiredStateTargets(desired string) []platform.InstanceState {
+	if desired == desiredStateStopped {
+		return []platform.InstanceState{platform.InstanceStateStopped}
+	}
+	return instanceRunningStates
+}
+
+// instanceTargetStates returns the states a newly created instance is awaited
+// in, based on its configured run state.
+func instanceTargetStates(data *InstanceResourceModel) []platform.InstanceState {
+	if !data.DesiredState.IsNull() && !data.DesiredState.IsUnknown() {
+		return desiredStateTargets(data.DesiredState.ValueString())
+	}
+	if data.Autostart.ValueBool() {
+		return instanceRunningStates
+	}
+	return []platform.InstanceState{platform.InstanceStateStopped}
+}
+
+// canRollout reports whether a change of image can be rolled out to an
+// instance with the given service group, volumes and configured name without
+// replacing the resource. This requires a service group which outlives the
+// instance, no volumes, which cannot be mounted by the old and new instance at
+// once, and no fixed name, which cannot be held by both instances.
+func canRollout(serviceGroupUUID types.String, volumes types.List, name types.String) bool {
+	return !serviceGroupUUID.IsNull() && !serviceGroupUUID.IsUnknown() &&
+		(volumes.IsNull() || (!volumes.IsUnknown() && len(volumes.Elements()) == 0)) &&
+		name.IsNull()
+}
+
+// waitInstanceState polls an instance until it reaches one of the given
+// states. When the instance is awaited in a running state but stops on its
+// own, waiting is aborted with the reason reported by the platform.
+func waitInstanceState(ctx context.Context, client platform.Client, uuid string, timeout time.Duration, targets ...platform.InstanceState) error {
+	var last platform.InstanceState
+	var lastStop string
+
+	err := waitFor(ctx, timeout, func(ctx context.Context) (bool, error) {
+		insResp, err := client.GetInstanceByUUID(ctx, uuid, true)
+		if err != nil {
+			return false, err
+		}
+		if insResp == nil || insResp.Data == nil || len(insResp.Data.Instances) == 0 {
+			return false, errors.New("empty response from get instance API")
+		}
+		ins := insResp.Data.Instances[0]
+
+		if ins.State == nil {
+			return false, nil
+		}
+		last = *ins.State
+
+		if slices.Contains(targets, last) {
+			return true, nil
+		}
+
+		if last == platform.InstanceStateStopped && stoppedUnexpectedly(&ins) {
+			// Instances with a restart policy are restarted by the platform,
+			// so waiting goes on in case the failure was transient.
+			if !restartsOnFailure(&ins) {
+				return false, fmt.Errorf("instance stopped unexpectedly (%s)", describeStop(&ins))
+			}
+			lastStop = describeStop(&ins)
+			if ins.Restart != nil && ins.Restart.Attempt != nil {
+				lastStop += fmt.Sprintf(", restart attempt %d", *ins.Restart.Attempt)
+			}
+		}
+
+		return false, nil
+	})
+	if err != nil && lastStop != "" {
+		return fmt.Errorf("%w (last state: %s, last stop: %s)", err, last, lastStop)
+	}
+	if err != nil && last != "" {
+		return fmt.Errorf("%w (last state: %s)", err, last)
+	}
+	return err
+}
+
+// restartsOnFailure reports whether the platform restarts an instance which
+// stopped because of a failure.
+func restartsOnFailure(ins *platform.Instance) bool {
+	return ins.RestartPolicy != nil && *ins.RestartPolicy != platform.InstanceRestartPolicyNever
+}
+
+// waitInstanceDeleted polls an instance until the platform no longer knows
+// about it.
+func waitInstanceDeleted(ctx context.Context, client platform.Client, uuid string, timeout time.Duration) error {
+	return waitFor(ctx, timeout, func(ctx context.Context) (bool, error) {
+		insResp, err := client.GetInstanceByUUID(ctx, uuid, false)
+		if isNotFound(err) {
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		return insResp == nil || insResp.Data == nil || len(insResp.Data.Instances) == 0, nil
+	})
+}
+
+// stoppedUnexpectedly reports whether a stopped instance was stopped by
+// something else than a user request, e.g. because its application exited or
+// crashed.
+func stoppedUnexpectedly(ins *platform.Instance) bool {
+	return ins.StopReason != nil && *ins.StopReason != platform.StopReasonUnknown &&
+		*ins.StopReason&platform.StopReasonUser == 0
+}
+
+// describeStop returns a human-readable description of the reason why an
+// instance stopped, including the exit code of its application.
+func describeStop(ins *platform.Instance) string {
+	if ins.ExitCode == nil {
+		return describeStopCause(ins)
+	}
+	return fmt.Sprintf("%s, exit code %d", describeStopCause(ins), *ins.ExitCode)
+}
+
+// describeStopCause returns a human-readable description of who or what
+// stopped an instance, and why.
+func describeStopCause(ins *platform.Instance) string {
+	parts := []string{ins.DescribeStopOrigin()}
+	if reason := ins.DescribeStopReason(); reason != "" {
+		parts = append(parts, reason)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// instanceStateSatisfies reports whether an instance in the given actual state
+// is in, or transitioning to, the desired run state.
+func instanceStateSatisfies(desired string, actual platform.InstanceState) bool {
+	switch actual {
+	case platform.InstanceStateStopped,
+		platform.InstanceStateStopping,
+		platform.InstanceStateDraining:
+		return desired == desiredStateStopped
+	case platform.InstanceStateStarting,
+		platform.InstanceStateRunning,
+		platform.InstanceStateStandby:
+		return desired == desiredStateRunning || desired == desiredStateStandby
+	default:
+		return false
+	}
+}
+
+// desiredStateOf returns the desired_state value which corresponds to the
+// given actual state of an instance.
+func desiredStateOf(actual platform.InstanceState) string {
+	switch actual {
+	case platform.InstanceStateStopped,
+		platform.InstanceStateStopping,
+		platform.InstanceStateDraining:
+		return desiredStateStopped
+	case platform.InstanceStateStandby:
+		return desiredStateStandby
+	case platform.InstanceStateStarting,
+		platform.InstanceStateRunning:
+		return desiredStateRunning
+	default:
+		return string(actual)
+	}
+}
+
+// imageDigest returns the digest of an image reference which is pinned to a
+// digest (e.g. "nginx@sha256:..."), or an empty string otherwise.
+func imageDigest(image string) string {
+	if i := strings.LastIndex(image, "@"); i >= 0 {
+		return image[i+1:]
+	}
+	return ""
+}
+
+// instanceCreateRequest returns the request which creates an instance as
+// described by the model.
+func instanceCreateRequest(ctx context.Context, data *InstanceResourceModel) (platform.CreateInstanceRequest, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	in := platform.CreateInstanceRequest{
+		Image: data.Image.ValueString(),
+	}
+
+	if !data.Name.IsNull() && !data.Name.IsUnknown() {
+		in.Name = data.Name.ValueStringPointer()
+	}
+
+	// New SDK properly handles optional fields with pointers
+	if !data.MemoryMB.IsUnknown() && !data.MemoryMB.IsNull() {
+		memoryMB := data.MemoryMB.ValueInt64()
+		in.MemoryMb = &memoryMB
+	}
+
+	if !data.VCPUs.IsUnknown() && !data.VCPUs.IsNull() {
+		vcpus := int32(data.VCPUs.ValueInt64())
+		in.Vcpus = &vcpus
+	}
+
+	if !data.Autostart.IsUnknown() && !data.Autostart.IsNull() {
+		autostart := data.Autostart.ValueBool()
+		in.Autostart = &autostart
+	}
+
+	if !data.DesiredState.IsUnknown() && !data.DesiredState.IsNull() {
+		autostart := data.DesiredState.ValueString() != desiredStateStopped
+		in.Autostart = &autostart
+	}
+
+	if !data.Args.IsNull() && !data.Args.IsUnknown() {
+		argVals := make([]types.String, 0, len(data.Args.Elements()))
+		diags.Append(data.Args.ElementsAs(ctx, &argVals, false)...)
+		for _, v := range argVals {
+			in.Args = append(in.Args, v.ValueString())
+		}
+	}
+
+	env, d := instanceEnv(ctx, data)
+	diags.Append(d...)
+	if len(env) > 0 {
+		in.Env = env
+	}
+
+	if !data.Volumes.IsNull() && !data.Volumes.IsUnknown() {
+		vols, d := platformVolumes(ctx, data.Volumes)
+		diags.Append(d...)
+		in.Volumes = vols
+	}
+
+	if !data.RestartPolicy.IsUnknown() && !data.RestartPolicy.IsNull() {
+		policy := platform.CreateInstanceRequestRestartPolicy(strings.ReplaceAll(data.RestartPolicy.ValueString(), "-", "_"))
+		in.RestartPolicy = &policy
+	}
+
+	if data.ScaleToZero != nil {
+		in.ScaleToZero = platformScaleToZero(data.ScaleToZero)
+	}
+
+	if !data.ServiceGroupUUID.IsNull() && !data.ServiceGroupUUID.IsUnknown() {
+		in.ServiceGroup = &platform.CreateInstanceRequestServiceGroup{
+			Uuid: data.ServiceGroupUUID.ValueStringPointer(),
+		}
+	}
+
+	if data.ServiceGroup != nil && (len(data.ServiceGroup.Services) > 0 || len(data.ServiceGroup.Domains) > 0) {
+		sgServices, d := platformServices(ctx, data.ServiceGroup.Services)
+		diags.Append(d...)
+		in.ServiceGroup = &platform.CreateInstanceRequestServiceGroup{
+			Services: sgServices,
+			Domains:  platformDomains(data.ServiceGroup.Domains),
+		}
+	}
+
+	return in, diags
+}
+
+// instanceUpdates returns the property changes required to bring an existing
+// instance from its prior state to the planned one.
+func instanceUpdates(ctx context.Context, plan, state *InstanceResourceModel) ([]platform.UpdateInstanceByUUIDRequestBody, diag.Diagnostics) {
+	var updates []platform.UpdateInstanceByUUIDRequestBody
+	var diags diag.Diagnostics
+
+	if !plan.MemoryMB.IsUnknown() && !plan.MemoryMB.IsNull() && !plan.MemoryMB.Equal(state.MemoryMB) {
+		val := any(plan.MemoryMB.ValueInt64())
+		updates = append(updates, platform.UpdateInstanceByUUIDRequestBody{
+			Prop:  platform.UpdateInstanceByUUIDRequestBodyPropMemory_mb,
+			Op:    platform.UpdateInstanceByUUIDRequestBodyOpSet,
+			Value: &val,
+		})
+	}
+
+	if !plan.VCPUs.IsUnknown() && !plan.VCPUs.IsNull() && !plan.VCPUs.Equal(state.VCPUs) {
+		val := any(plan.VCPUs.ValueInt64())
+		updates = append(updates, platform.UpdateInstanceByUUIDRequestBody{
+			Prop:  platform.UpdateInstanceByUUIDRequestBodyPropVcpus,
+			Op:    platform.UpdateInstanceByUUIDRequestBodyOpSet,
+			Value: &val,
+		})
+	}
+
+	if !plan.Args.IsUnknown() && !plan.Args.IsNull() && !plan.Args.Equal(state.Args) {
+		args := make([]string, 0, len(plan.Args.Elements()))
+		diags.Append(plan.Args.ElementsAs(ctx, &args, false)...)
+		val := any(args)
+		updates = append(updates, platform.UpdateInstanceByUUIDRequestBody{
+			Prop:  platform.UpdateInstanceByUUIDRequestBodyPropArgs,
+			Op:    platform.UpdateInstanceByUUIDRequestBodyOpSet,
+			Value: &val,
+		})
+	}
+
+	if !plan.Env.Equal(state.Env) || !plan.SecretEnv.Equal(state.SecretEnv) {
+		env, d := instanceEnv(ctx, plan)
+		diags.Append(d...)
+		if env == nil {
+			env = map[string]string{}
+		}
+		val := any(env)
+		updates = append(updates, platform.UpdateInstanceByUUIDRequestBody{
+			Prop:  platform.UpdateInstanceByUUIDRequestBodyPropEnv,
+			Op:    platform.UpdateInstanceByUUIDRequestBodyOpSet,
+			Value: &val,
+		})
+	}
+
+	if scaleToZeroChanged(plan.ScaleToZero, state.ScaleToZero) {
+		stz := platformScaleToZero(plan.ScaleToZero)
+		if stz == nil {
+			policy := platform.CreateInstanceRequestScaleToZeroPolicyOff
+			stz = &platform.CreateInstanceRequestScaleToZero{Policy: &policy}
+		}
+		val := any(stz)
+		updates = append(updates, platform.UpdateInstanceByUUIDRequestBody{
+			Prop:  platform.UpdateInstanceByUUIDRequestBodyPropScale_to_zero,
+			Op:    platform.UpdateInstanceByUUIDRequestBodyOpSet,
+			Value: &val,
+		})
+	}
+
+	return updates, diags
+}
+
+// platformScaleToZero returns the scale-to-zero configuration of the API which
+// corresponds to the given model. Values which are not known yet are left for
+// the platform to decide.
+func platformScaleToZero(stz *models.ScaleToZeroModel) *platform.CreateInstanceRequestScaleToZero {
+	if stz == nil {
+		return nil
+	}
+
+	out := &platform.CreateInstanceRequestScaleToZero{}
+	if !stz.Policy.IsNull() && !stz.Policy.IsUnknown() {
+		policy := platform.CreateInstanceRequestScaleToZeroPolicy(stz.Policy.ValueString())
+		out.Policy = &policy
+	}
+	if !stz.Stateful.IsNull() && !stz.Stateful.IsUnknown() {
+		out.Stateful = stz.Stateful.ValueBoolPointer()
+	}
+	if !stz.CooldownTimeMs.IsNull() && !stz.CooldownTimeMs.IsUnknown() {
+		cooldown := int32(stz.CooldownTimeMs.ValueInt64())
+		out.CooldownTimeMs = &cooldown
+	}
+	return out
+}
+
+// scaleToZeroChanged reports whether the planned scale-to-zero configuration
+// differs from the prior one. Unknown planned values are left for the platform
+// to decide and therefore do not count as changes.
+func scaleToZeroChanged(plan, state *models.ScaleToZeroModel) bool {
+	if plan == nil || state == nil {
+		return (plan == nil) != (state == nil)
+	}
+	return (!plan.Policy.IsUnknown() && !plan.Policy.Equal(state.Policy)) ||
+		(!plan.Stateful.IsUnknown() && !plan.Stateful.Equal(state.Stateful)) ||
+		(!plan.CooldownTimeMs.IsUnknown() && !plan.CooldownTimeMs.Equal(state.CooldownTimeMs))
+}
+
+// readScaleToZero populates the scale-to-zero configuration of the model from
+// the one reported by the API.
+func readScaleToZero(apiStz *platform.InstanceScaleToZero, stz *models.ScaleToZeroModel) {
+	if apiStz == nil {
+		apiStz = &platform.InstanceScaleToZero{}
+	}
+
+	if apiStz.Policy != nil {
+		stz.Policy = types.StringValue(string(*apiStz.Policy))
+	} else {
+		stz.Policy = types.StringValue(string(platform.InstanceScaleToZeroPolicyOff))
+	}
+	stz.Stateful = types.BoolValue(apiStz.Stateful != nil && *apiStz.Stateful)
+	if apiStz.CooldownTimeMs != nil {
+		stz.CooldownTimeMs = types.Int64Value(int64(*apiStz.CooldownTimeMs))
+	} else {
+		stz.CooldownTimeMs = types.Int64Null()
+	}
+}
+
+// instanceEnv merges the plain and secret environment variables of the model
+// into the single set of variables expected by the API.
+func instanceEnv(ctx context.Context, data *InstanceResourceModel) (map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var env map[string]string
+
+	if !data.Env.IsNull() && !data.Env.IsUnknown() {
+		diags.Append(data.Env.ElementsAs(ctx, &env, false)...)
+	}
+
+	if !data.SecretEnv.IsNull() && !data.SecretEnv.IsUnknown() {
+		var secretEnv map[string]string
+		diags.Append(data.SecretEnv.ElementsAs(ctx, &secretEnv, false)...)
+
+		if env == nil {
+			env = make(map[string]string, len(secretEnv))
+		}
+		for k, v := range secretEnv {
+			if _, ok := env[k]; ok {
+				diags.AddAttributeError(
+					path.Root("secret_env").AtMapKey(k),
+					"Conflicting Environment Variable",
+					fmt.Sprintf("The environment variable %q is set in both env and secret_env.", k),
+				)
+				continue
+			}
+			env[k] = v
+		}
+	}
+
+	return env, diags
+}
+
+// readInstanceEnv splits the environment variables reported by the API
+// between the plain and secret environment variables of the model.
+//
+// Variables are attributed to secret_env when their key is already tracked
+// there. Only keys that are already tracked in env are retained, so that
+// variables inherited from the image do not cause a perpetual diff, and env
+// stays null when it is not configured.
+func readInstanceEnv(ctx context.Context, apiEnv map[string]string, data *InstanceResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var d diag.Diagnostics
+
+	var secretKeys map[string]string
+	if !data.SecretEnv.IsNull() && !data.SecretEnv.IsUnknown() {
+		diags.Append(data.SecretEnv.ElementsAs(ctx, &secretKeys, false)...)
+	}
+
+	var envKeys map[string]string
+	filterEnv := !data.Env.IsNull() && !data.Env.IsUnknown()
+	if filterEnv {
+		diags.Append(data.Env.ElementsAs(ctx, &envKeys, false)...)
+	}
+
+	env := make(map[string]string)
+	secretEnv := make(map[string]string)
+	for k, v := range apiEnv {
+		if _, ok := secretKeys[k]; ok {
+			secretEnv[k] = v
+			continue
+		}
+		if _, ok := envKeys[k]; filterEnv && !ok {
+			continue
+		}
+		env[k] = v
+	}
+
+	if filterEnv {
+		data.Env, d = types.MapValueFrom(ctx, types.StringType, env)
+		diags.Append(d...)
+	}
+
+	if secretKeys != nil {
+		data.SecretEnv, d = types.MapValueFrom(ctx, types.StringType, secretEnv)
+		diags.Append(d...)
+	}
+
+	return diags
+}
+
+// platformVolumes converts the volumes of an instance's data model to volume
+// mounts of a create request.
+func platformVolumes(ctx context.Context, volumes types.List) ([]platform.CreateInstanceRequestVolume, diag.Diagnostics) {
+	var vols []models.InstanceVolumeModel
+	diags := volumes.ElementsAs(ctx, &vols, false)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	out := make([]platform.CreateInstanceRequestVolume, 0, len(vols))
+	for _, v := range vols {
+		vol := platform.CreateInstanceRequestVolume{
+			At: v.At.ValueString(),
+		}
+		if !v.UUID.IsNull() && !v.UUID.IsUnknown() {
+			vol.Uuid = v.UUID.ValueStringPointer()
+		}
+		if !v.Name.IsNull() && !v.Name.IsUnknown() {
+			vol.Name = v.Name.ValueStringPointer()
+		}
+		if !v.ReadOnly.IsNull() && !v.ReadOnly.IsUnknown() {
+			vol.Readonly = v.ReadOnly.ValueBoolPointer()
+		}
+		out = append(out, vol)
+	}
+
+	return out, diags
+}
+
+// readInstanceVolumes populates the volumes of an instance's data model with
+// the volumes mounted by the instance. Configured volumes are matched by mount
+// path, and dropped when no longer mounted so that the instance gets replaced.
+// Volumes mounted outside of this attribute, e.g. by a volume attachment, are
+// ignored unless the instance is being imported.
+func readInstanceVolumes(ctx context.Context, apiVols []platform.InstanceVolume, data *InstanceResourceModel, importing bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var vols []models.InstanceVolumeModel
+	if !data.Volumes.IsNull() && !data.Volumes.IsUnknown() {
+		diags.Append(data.Volumes.ElementsAs(ctx, &vols, false)...)
+		if diags.HasError() {
+			return diags
+		}
+	} else if !importing || len(apiVols) == 0 {
+		data.Volumes = types.ListNull(models.InstanceVolumeModelType)
+		return diags
+	} else {
+		for _, v := range apiVols {
+			vols = append(vols, models.InstanceVolumeModel{At: types.StringPointerValue(v.At)})
+		}
+	}
+
+	out := make([]models.InstanceVolumeModel, 0, len(vols))
+	for _, v := range vols {
+		idx := slices.IndexFunc(apiVols, func(av platform.InstanceVolume) bool {
+			return av.At != nil && *av.At == v.At.ValueString()
+		})
+		if idx < 0 {
+			continue
+		}
+		av := apiVols[idx]
+
+		v.UUID = types.StringPointerValue(av.Uuid)
+		v.Name = types.StringPointerValue(av.Name)
+		v.ReadOnly = types.BoolValue(av.Readonly != nil && *av.Readonly)
+		out = append(out, v)
+	}
+
+	var d diag.Diagnostics
+	data.Volumes, d = types.ListValueFrom(ctx, models.InstanceVolumeModelType, out)
+	diags.Append(d...)
+
+	return diags
 }
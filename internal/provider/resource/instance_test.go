@@ -5,13 +5,25 @@ package resource
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	providerMock "github.com/unikraft-cloud/terraform-provider-unikraft-cloud/internal/provider/mock"
+	models "github.com/unikraft-cloud/terraform-provider-unikraft-cloud/internal/provider/model"
+
+	"unikraft.com/cloud/sdk/platform"
 )
 
 func TestInstanceResource_Metadata(t *testing.T) {
@@ -38,6 +50,19 @@ func TestInstanceResource_Schema(t *testing.T) {
 	assert.Contains(t, resp.Schema.Attributes, "uuid")
 	assert.Contains(t, resp.Schema.Attributes, "memory_mb")
 	assert.Contains(t, resp.Schema.Attributes, "service_group")
+	assert.Contains(t, resp.Schema.Attributes, "volumes")
+}
+
+// The update API of the platform cannot change autostart, so changing it must
+// replace the instance rather than plan an update which does nothing.
+func TestInstanceResource_Schema_AutostartRequiresReplace(t *testing.T) {
+	resp := &resource.SchemaResponse{}
+	NewInstanceResource().Schema(context.Background(), resource.SchemaRequest{}, resp)
+
+	attr, ok := resp.Schema.Attributes["autostart"].(schema.BoolAttribute)
+	if assert.True(t, ok) && assert.Len(t, attr.PlanModifiers, 1) {
+		assert.Contains(t, attr.PlanModifiers[0].Description(context.Background()), "destroy and recreate")
+	}
 }
 
 func TestInstanceResource_Configure_Success(t *testing.T) {
@@ -84,10 +109,209 @@ func TestInstanceResource_Configure_InvalidProviderDataType(t *testing.T) {
 	assert.Contains(t, resp.Diagnostics.Errors()[0].Summary(), "Unexpected Resource Configure Type")
 }
 
-// NOTE: Create, Read, Update, and Delete operations should be tested via acceptance tests.
-// Unit testing these operations would require complex mocking of Terraform's plugin
-// framework request/response structures (Plan, State, etc.), which provides limited value
-// compared to acceptance tests that verify actual behavior against a real backend.
+// testInstance returns a response of the get instance API describing an
+// instance in the given state.
+func testInstance(uuid string, state platform.InstanceState) *platform.Response[platform.GetInstancesResponseData] {
+	name := "ins-" + uuid
+	return &platform.Response[platform.GetInstancesResponseData]{
+		Status: "success",
+		Data: &platform.GetInstancesResponseData{
+			Instances: []platform.Instance{{
+				Uuid:  &uuid,
+				Name:  &name,
+				State: &state,
+			}},
+		},
+	}
+}
+
+// crashedInstance returns a response of the get instance API describing an
+// instance whose application exited and which is not restarted.
+func crashedInstance(uuid string) *platform.Response[platform.GetInstancesResponseData] {
+	resp := testInstance(uuid, platform.InstanceStateStopped)
+	reason := platform.StopReasonAppExit
+	policy := platform.InstanceRestartPolicyNever
+	resp.Data.Instances[0].StopReason = &reason
+	resp.Data.Instances[0].RestartPolicy = &policy
+	return resp
+}
+
+func TestInstanceResource_Create_WaitFails(t *testing.T) {
+	ctx := context.Background()
+	mockClient := new(providerMock.PlatformClient)
+	r := &InstanceResource{clients: testPool(mockClient)}
+
+	uuid := "new-uuid"
+	mockClient.On("CreateInstance", mock.Anything, mock.Anything).Return(&platform.Response[platform.CreateInstanceResponseData]{
+		Status: "success",
+		Data:   &platform.CreateInstanceResponseData{Instances: []platform.Instance{{Uuid: &uuid}}},
+	}, nil)
+	mockClient.On("GetInstanceByUUID", mock.Anything, uuid, true).Return(crashedInstance(uuid), nil)
+
+	req := resource.CreateRequest{
+		Plan: testPlan(t, r, map[string]attr.Value{
+			"image":         types.StringValue("nginx:latest"),
+			"desired_state": types.StringValue(desiredStateRunning),
+			"uuid":          types.StringUnknown(),
+		}),
+	}
+	resp := &resource.CreateResponse{State: testState(t, r)}
+
+	r.Create(ctx, req, resp)
+
+	// The instance is saved, so that Terraform taints it instead of creating
+	// another one on the next apply.
+	assert.True(t, resp.Diagnostics.HasError())
+	var got InstanceResourceModel
+	assert.False(t, resp.State.Get(ctx, &got).HasError())
+	assert.Equal(t, uuid, got.UUID.ValueString())
+	assert.Equal(t, string(platform.InstanceStateStopped), got.State.ValueString())
+	mockClient.AssertNotCalled(t, "DeleteInstanceByUUID", mock.Anything, mock.Anything)
+}
+
+// rolloutRequest returns a request to update the instance old-uuid of the
+// service group sg-uuid to a new image, which rolls out a new instance.
+func rolloutRequest(t *testing.T, r *InstanceResource) resource.UpdateRequest {
+	t.Helper()
+
+	state := testPlan(t, r, map[string]attr.Value{
+		"uuid":               types.StringValue("old-uuid"),
+		"image":              types.StringValue("nginx:1"),
+		"desired_state":      types.StringValue(desiredStateRunning),
+		"service_group_uuid": types.StringValue("sg-uuid"),
+	})
+	plan := testPlan(t, r, map[string]attr.Value{
+		"uuid":               types.StringUnknown(),
+		"image":              types.StringValue("nginx:2"),
+		"desired_state":      types.StringValue(desiredStateRunning),
+		"service_group_uuid": types.StringValue("sg-uuid"),
+	})
+
+	return resource.UpdateRequest{
+		Plan:  plan,
+		State: tfsdk.State{Schema: state.Schema, Raw: state.Raw},
+	}
+}
+
+// mockRolloutCreate mocks the creation of the instance new-uuid, which
+// reaches the given state.
+func mockRolloutCreate(m *providerMock.PlatformClient, ins *platform.Response[platform.GetInstancesResponseData]) {
+	uuid := "new-uuid"
+	m.On("CreateInstance", mock.Anything, mock.MatchedBy(func(in platform.CreateInstanceRequest) bool {
+		return in.ServiceGroup != nil && *in.ServiceGroup.Uuid == "sg-uuid"
+	})).Return(&platform.Response[platform.CreateInstanceResponseData]{
+		Status: "success",
+		Data:   &platform.CreateInstanceResponseData{Instances: []platform.Instance{{Uuid: &uuid}}},
+	}, nil)
+	m.On("GetInstanceByUUID", mock.Anything, uuid, true).Return(ins, nil)
+}
+
+func TestInstanceResource_Update_Rollout(t *testing.T) {
+	ctx := context.Background()
+	mockClient := new(providerMock.PlatformClient)
+	r := &InstanceResource{clients: testPool(mockClient)}
+
+	mockRolloutCreate(mockClient, testInstance("new-uuid", platform.InstanceStateRunning))
+	mockClient.On("StopInstanceByUUID", mock.Anything, "old-uuid", false, mock.Anything).Return(&platform.Response[platform.StopInstancesResponseData]{Status: "success"}, nil)
+	mockClient.On("GetInstanceByUUID", mock.Anything, "old-uuid", true).Return(testInstance("old-uuid", platform.InstanceStateStopped), nil)
+	mockClient.On("DeleteInstanceByUUID", mock.Anything, "old-uuid").Return(&platform.Response[platform.DeleteInstancesResponseData]{Status: "success"}, nil)
+	mockClient.On("GetInstanceByUUID", mock.Anything, "old-uuid", false).Return(nil, &fakeAPIError{`{"status":"error","errors":[{"status":404}]}`})
+
+	req := rolloutRequest(t, r)
+	resp := &resource.UpdateResponse{State: req.State}
+
+	r.Update(ctx, req, resp)
+
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	var got InstanceResourceModel
+	assert.False(t, resp.State.Get(ctx, &got).HasError())
+	assert.Equal(t, "new-uuid", got.UUID.ValueString())
+	mockClient.AssertCalled(t, "DeleteInstanceByUUID", mock.Anything, "old-uuid")
+}
+
+func TestInstanceResource_Update_RolloutNotReady(t *testing.T) {
+	ctx := context.Background()
+	mockClient := new(providerMock.PlatformClient)
+	r := &InstanceResource{clients: testPool(mockClient)}
+
+	mockRolloutCreate(mockClient, crashedInstance("new-uuid"))
+	mockClient.On("DeleteInstanceByUUID", mock.Anything, "new-uuid").Return(&platform.Response[platform.DeleteInstancesResponseData]{Status: "success"}, nil)
+
+	req := rolloutRequest(t, r)
+	resp := &resource.UpdateResponse{State: req.State}
+
+	r.Update(ctx, req, resp)
+
+	// The old instance is left in place, and so is the state.
+	assert.True(t, resp.Diagnostics.HasError())
+	var got InstanceResourceModel
+	assert.False(t, resp.State.Get(ctx, &got).HasError())
+	assert.Equal(t, "old-uuid", got.UUID.ValueString())
+	mockClient.AssertCalled(t, "DeleteInstanceByUUID", mock.Anything, "new-uuid")
+	mockClient.AssertNotCalled(t, "StopInstanceByUUID", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestInstanceResource_Update_RolloutRetireFails(t *testing.T) {
+	ctx := context.Background()
+	mockClient := new(providerMock.PlatformClient)
+	r := &InstanceResource{clients: testPool(mockClient)}
+
+	mockRolloutCreate(mockClient, testInstance("new-uuid", platform.InstanceStateRunning))
+	mockClient.On("StopInstanceByUUID", mock.Anything, "old-uuid", false, mock.Anything).Return(nil, errors.New("connection reset"))
+
+	req := rolloutRequest(t, r)
+	resp := &resource.UpdateResponse{State: req.State}
+
+	r.Update(ctx, req, resp)
+
+	// The replacement is saved, and the old instance is reported.
+	assert.True(t, resp.Diagnostics.HasError())
+	var got InstanceResourceModel
+	assert.False(t, resp.State.Get(ctx, &got).HasError())
+	assert.Equal(t, "new-uuid", got.UUID.ValueString())
+	assert.Equal(t, string(platform.InstanceStateRunning), got.State.ValueString())
+
+	var details []string
+	for _, d := range resp.Diagnostics.Errors() {
+		details = append(details, d.Detail())
+	}
+	assert.Contains(t, strings.Join(details, "\n"), "old-uuid was replaced by new-uuid")
+}
+
+func TestInstanceResource_UpgradeStateV0(t *testing.T) {
+	ctx := context.Background()
+	r := &InstanceResource{}
+
+	raw := &tfprotov6.RawState{JSON: []byte(`{
+		"uuid": "ins-uuid",
+		"image": "nginx:latest",
+		"service_group": {
+			"uuid": "sg-uuid",
+			"domains": [
+				{"name": "example.com.", "fqdn": "example.com", "certificate": {"example.com": {"uuid": "crt-uuid", "name": "crt", "state": "valid"}}},
+				{"name": "www", "fqdn": null, "certificate": null}
+			]
+		}
+	}`)}
+
+	upgrader, ok := r.UpgradeState(ctx)[0]
+	require.True(t, ok)
+
+	resp := &resource.UpgradeStateResponse{State: testState(t, r)}
+	upgrader.StateUpgrader(ctx, resource.UpgradeStateRequest{RawState: raw}, resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var got InstanceResourceModel
+	require.False(t, resp.State.Get(ctx, &got).HasError())
+	assert.Equal(t, "ins-uuid", got.UUID.ValueString())
+	assert.True(t, got.Metro.IsNull(), "attributes added since are null")
+	if assert.NotNil(t, got.ServiceGroup) && assert.Len(t, got.ServiceGroup.Domains, 2) {
+		crt := got.ServiceGroup.Domains[0].Certificate.Attributes()
+		assert.Equal(t, types.StringValue("crt-uuid"), crt["uuid"])
+		assert.Equal(t, types.StringValue("valid"), crt["state"])
+		assert.True(t, got.ServiceGroup.Domains[1].Certificate.IsNull())
+	}
+}
 
 func TestNewInstanceResource(t *testing.T) {
 	r := NewInstanceResource()
@@ -95,16 +319,451 @@ func TestNewInstanceResource(t *testing.T) {
 	assert.IsType(t, &InstanceResource{}, r)
 }
 
-func TestInstanceResource_Update(t *testing.T) {
-	r := &InstanceResource{}
+func TestInstanceUpdates_NoChanges(t *testing.T) {
+	state := InstanceResourceModel{
+		MemoryMB:  types.Int64Value(128),
+		Args:      types.ListValueMust(types.StringType, []attr.Value{types.StringValue("-v")}),
+		Env:       types.MapNull(types.StringType),
+		SecretEnv: types.MapNull(types.StringType),
+	}
+	plan := state
 
-	req := resource.UpdateRequest{}
-	resp := &resource.UpdateResponse{}
+	updates, diags := instanceUpdates(context.Background(), &plan, &state)
 
-	r.Update(context.Background(), req, resp)
+	assert.False(t, diags.HasError())
+	assert.Empty(t, updates)
+}
 
-	assert.True(t, resp.Diagnostics.HasError())
-	assert.Contains(t, resp.Diagnostics.Errors()[0].Summary(), "Unsupported")
+func TestInstanceUpdates_Changes(t *testing.T) {
+	state := InstanceResourceModel{
+		MemoryMB:  types.Int64Value(128),
+		Args:      types.ListValueMust(types.StringType, []attr.Value{types.StringValue("-v")}),
+		Env:       types.MapNull(types.StringType),
+		SecretEnv: types.MapNull(types.StringType),
+	}
+	plan := InstanceResourceModel{
+		MemoryMB:  types.Int64Value(256),
+		Args:      types.ListValueMust(types.StringType, []attr.Value{types.StringValue("-vv")}),
+		Env:       types.MapNull(types.StringType),
+		SecretEnv: types.MapNull(types.StringType),
+	}
+
+	updates, diags := instanceUpdates(context.Background(), &plan, &state)
+
+	assert.False(t, diags.HasError())
+	if assert.Len(t, updates, 2) {
+		assert.Equal(t, platform.UpdateInstanceByUUIDRequestBodyPropMemory_mb, updates[0].Prop)
+		assert.Equal(t, int64(256), *updates[0].Value)
+		assert.Equal(t, platform.UpdateInstanceByUUIDRequestBodyPropArgs, updates[1].Prop)
+		assert.Equal(t, []string{"-vv"}, *updates[1].Value)
+	}
+}
+
+func TestInstanceUpdates_ScaleToZeroRemoved(t *testing.T) {
+	state := InstanceResourceModel{
+		Env:       types.MapNull(types.StringType),
+		SecretEnv: types.MapNull(types.StringType),
+		ScaleToZero: &models.ScaleToZeroModel{
+			Policy:         types.StringValue("on"),
+			Stateful:       types.BoolValue(false),
+			CooldownTimeMs: types.Int64Value(1000),
+		},
+	}
+	plan := state
+	plan.ScaleToZero = nil
+
+	updates, diags := instanceUpdates(context.Background(), &plan, &state)
+
+	assert.False(t, diags.HasError())
+	if assert.Len(t, updates, 1) {
+		assert.Equal(t, platform.UpdateInstanceByUUIDRequestBodyPropScale_to_zero, updates[0].Prop)
+		stz := (*updates[0].Value).(*platform.CreateInstanceRequestScaleToZero)
+		assert.Equal(t, platform.CreateInstanceRequestScaleToZeroPolicyOff, *stz.Policy)
+	}
+}
+
+func TestInstanceUpdates_EnvRemoved(t *testing.T) {
+	state := InstanceResourceModel{
+		Env:       types.MapValueMust(types.StringType, map[string]attr.Value{"LOG_LEVEL": types.StringValue("info")}),
+		SecretEnv: types.MapNull(types.StringType),
+	}
+	plan := state
+	plan.Env = types.MapNull(types.StringType)
+
+	updates, diags := instanceUpdates(context.Background(), &plan, &state)
+
+	assert.False(t, diags.HasError())
+	if assert.Len(t, updates, 1) {
+		assert.Equal(t, platform.UpdateInstanceByUUIDRequestBodyPropEnv, updates[0].Prop)
+		assert.Equal(t, map[string]string{}, *updates[0].Value)
+	}
+}
+
+// instanceUpdate returns an update which sets the given property of an
+// instance.
+func instanceUpdate(prop platform.UpdateInstanceByUUIDRequestBodyProp, v any) platform.UpdateInstanceByUUIDRequestBody {
+	return platform.UpdateInstanceByUUIDRequestBody{Prop: prop, Op: platform.UpdateInstanceByUUIDRequestBodyOpSet, Value: &v}
+}
+
+func TestApplyInstanceUpdates_Live(t *testing.T) {
+	update := instanceUpdate(platform.UpdateInstanceByUUIDRequestBodyPropScale_to_zero, &platform.CreateInstanceRequestScaleToZero{})
+	mockClient := new(providerMock.PlatformClient)
+	mockClient.On("UpdateInstanceByUUID", mock.Anything, "ins-uuid", update).Return(&platform.Response[platform.UpdateInstancesResponseData]{}, nil)
+
+	diags := applyInstanceUpdates(context.Background(), mockClient.Client(), "ins-uuid", []platform.UpdateInstanceByUUIDRequestBody{update}, true, time.Minute)
+
+	require.False(t, diags.HasError(), diags)
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNotCalled(t, "StopInstanceByUUID", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestApplyInstanceUpdates_Restart(t *testing.T) {
+	live := instanceUpdate(platform.UpdateInstanceByUUIDRequestBodyPropScale_to_zero, &platform.CreateInstanceRequestScaleToZero{})
+	memory := instanceUpdate(platform.UpdateInstanceByUUIDRequestBodyPropMemory_mb, int64(256))
+	mockClient := new(providerMock.PlatformClient)
+	mockClient.On("GetInstanceByUUID", mock.Anything, "ins-uuid", false).Return(testInstance("ins-uuid", platform.InstanceStateRunning), nil)
+	mockClient.On("StopInstanceByUUID", mock.Anything, "ins-uuid", false, int32(0)).Return(&platform.Response[platform.StopInstancesResponseData]{}, nil)
+	mockClient.On("GetInstanceByUUID", mock.Anything, "ins-uuid", true).Return(testInstance("ins-uuid", platform.InstanceStateStopped), nil).Once()
+	mockClient.On("UpdateInstanceByUUID", mock.Anything, "ins-uuid", mock.Anything).Return(&platform.Response[platform.UpdateInstancesResponseData]{}, nil)
+	mockClient.On("StartInstanceByUUID", mock.Anything, "ins-uuid").Return(&platform.Response[platform.StartInstancesResponseData]{}, nil)
+	mockClient.On("GetInstanceByUUID", mock.Anything, "ins-uuid", true).Return(testInstance("ins-uuid", platform.InstanceStateRunning), nil)
+
+	diags := applyInstanceUpdates(context.Background(), mockClient.Client(), "ins-uuid", []platform.UpdateInstanceByUUIDRequestBody{memory, live}, true, time.Minute)
+
+	require.False(t, diags.HasError(), diags)
+	mockClient.AssertExpectations(t)
+
+	// Live properties are applied before the instance is stopped.
+	var props []platform.UpdateInstanceByUUIDRequestBodyProp
+	for _, c := range mockClient.Calls {
+		if c.Method == "UpdateInstanceByUUID" {
+			props = append(props, c.Arguments.Get(2).(platform.UpdateInstanceByUUIDRequestBody).Prop)
+		}
+	}
+	assert.Equal(t, []platform.UpdateInstanceByUUIDRequestBodyProp{live.Prop, memory.Prop}, props)
+}
+
+func TestPlatformScaleToZero(t *testing.T) {
+	assert.Nil(t, platformScaleToZero(nil))
+
+	stz := platformScaleToZero(&models.ScaleToZeroModel{
+		Policy:         types.StringValue("idle"),
+		Stateful:       types.BoolUnknown(),
+		CooldownTimeMs: types.Int64Value(5000),
+	})
+
+	assert.Equal(t, platform.CreateInstanceRequestScaleToZeroPolicyIdle, *stz.Policy)
+	assert.Nil(t, stz.Stateful)
+	assert.Equal(t, int32(5000), *stz.CooldownTimeMs)
+}
+
+func TestScaleToZeroChanged(t *testing.T) {
+	state := &models.ScaleToZeroModel{
+		Policy:         types.StringValue("on"),
+		Stateful:       types.BoolValue(true),
+		CooldownTimeMs: types.Int64Value(1000),
+	}
+
+	assert.False(t, scaleToZeroChanged(nil, nil))
+	assert.True(t, scaleToZeroChanged(nil, state))
+	assert.True(t, scaleToZeroChanged(state, nil))
+	assert.False(t, scaleToZeroChanged(state, state))
+	assert.False(t, scaleToZeroChanged(&models.ScaleToZeroModel{
+		Policy:         types.StringValue("on"),
+		Stateful:       types.BoolUnknown(),
+		CooldownTimeMs: types.Int64Unknown(),
+	}, state))
+	assert.True(t, scaleToZeroChanged(&models.ScaleToZeroModel{
+		Policy:         types.StringValue("on"),
+		Stateful:       types.BoolValue(false),
+		CooldownTimeMs: types.Int64Unknown(),
+	}, state))
+}
+
+func TestReadScaleToZero(t *testing.T) {
+	policy := platform.InstanceScaleToZeroPolicyOn
+	cooldown := int32(1000)
+	var stz models.ScaleToZeroModel
+
+	readScaleToZero(&platform.InstanceScaleToZero{Policy: &policy, CooldownTimeMs: &cooldown}, &stz)
+
+	assert.Equal(t, "on", stz.Policy.ValueString())
+	assert.False(t, stz.Stateful.ValueBool())
+	assert.Equal(t, int64(1000), stz.CooldownTimeMs.ValueInt64())
+
+	readScaleToZero(nil, &stz)
+
+	assert.Equal(t, "off", stz.Policy.ValueString())
+	assert.True(t, stz.CooldownTimeMs.IsNull())
+}
+
+func TestInstanceEnv(t *testing.T) {
+	data := InstanceResourceModel{
+		Env:       types.MapValueMust(types.StringType, map[string]attr.Value{"LOG_LEVEL": types.StringValue("info")}),
+		SecretEnv: types.MapValueMust(types.StringType, map[string]attr.Value{"DB_PASSWORD": types.StringValue("hunter2")}),
+	}
+
+	env, diags := instanceEnv(context.Background(), &data)
+
+	assert.False(t, diags.HasError())
+	assert.Equal(t, map[string]string{"LOG_LEVEL": "info", "DB_PASSWORD": "hunter2"}, env)
+}
+
+func TestInstanceEnv_Conflict(t *testing.T) {
+	data := InstanceResourceModel{
+		Env:       types.MapValueMust(types.StringType, map[string]attr.Value{"TOKEN": types.StringValue("a")}),
+		SecretEnv: types.MapValueMust(types.StringType, map[string]attr.Value{"TOKEN": types.StringValue("b")}),
+	}
+
+	_, diags := instanceEnv(context.Background(), &data)
+
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags.Errors()[0].Summary(), "Conflicting Environment Variable")
+}
+
+func TestReadInstanceEnv(t *testing.T) {
+	data := InstanceResourceModel{
+		Env:       types.MapValueMust(types.StringType, map[string]attr.Value{"LOG_LEVEL": types.StringValue("info")}),
+		SecretEnv: types.MapValueMust(types.StringType, map[string]attr.Value{"DB_PASSWORD": types.StringValue("hunter2")}),
+	}
+	apiEnv := map[string]string{
+		"LOG_LEVEL":   "debug",
+		"DB_PASSWORD": "hunter2",
+		"PATH":        "/usr/bin", // inherited from the image
+	}
+
+	diags := readInstanceEnv(context.Background(), apiEnv, &data)
+
+	assert.False(t, diags.HasError())
+	assert.Equal(t, types.MapValueMust(types.StringType, map[string]attr.Value{"LOG_LEVEL": types.StringValue("debug")}), data.Env)
+	assert.Equal(t, types.MapValueMust(types.StringType, map[string]attr.Value{"DB_PASSWORD": types.StringValue("hunter2")}), data.SecretEnv)
+}
+
+func TestReadInstanceEnv_NotConfigured(t *testing.T) {
+	data := InstanceResourceModel{
+		Env:       types.MapNull(types.StringType),
+		SecretEnv: types.MapNull(types.StringType),
+	}
+
+	diags := readInstanceEnv(context.Background(), map[string]string{"PATH": "/usr/bin"}, &data)
+
+	assert.False(t, diags.HasError())
+	assert.True(t, data.Env.IsNull())
+}
+
+func TestPlatformVolumes(t *testing.T) {
+	volumes := types.ListValueMust(models.InstanceVolumeModelType, []attr.Value{
+		types.ObjectValueMust(models.InstanceVolumeModelType.AttrTypes, map[string]attr.Value{
+			"uuid":      types.StringUnknown(),
+			"name":      types.StringValue("data"),
+			"at":        types.StringValue("/data"),
+			"read_only": types.BoolUnknown(),
+		}),
+	})
+
+	vols, diags := platformVolumes(context.Background(), volumes)
+
+	assert.False(t, diags.HasError())
+	assert.Len(t, vols, 1)
+	assert.Nil(t, vols[0].Uuid)
+	assert.Equal(t, "data", *vols[0].Name)
+	assert.Equal(t, "/data", vols[0].At)
+	assert.Nil(t, vols[0].Readonly)
+}
+
+func TestReadInstanceVolumes(t *testing.T) {
+	uuid, name, at, ro := "vol-uuid", "data", "/data", true
+	otherAt := "/cache"
+	apiVols := []platform.InstanceVolume{
+		{Uuid: &uuid, Name: &name, At: &at, Readonly: &ro},
+		{Uuid: &uuid, Name: &name, At: &otherAt},
+	}
+
+	data := InstanceResourceModel{
+		Volumes: types.ListValueMust(models.InstanceVolumeModelType, []attr.Value{
+			types.ObjectValueMust(models.InstanceVolumeModelType.AttrTypes, map[string]attr.Value{
+				"uuid":      types.StringUnknown(),
+				"name":      types.StringValue("data"),
+				"at":        types.StringValue("/data"),
+				"read_only": types.BoolUnknown(),
+			}),
+		}),
+	}
+
+	diags := readInstanceVolumes(context.Background(), apiVols, &data, false)
+	assert.False(t, diags.HasError())
+
+	var vols []models.InstanceVolumeModel
+	data.Volumes.ElementsAs(context.Background(), &vols, false)
+	assert.Len(t, vols, 1)
+	assert.Equal(t, "vol-uuid", vols[0].UUID.ValueString())
+	assert.True(t, vols[0].ReadOnly.ValueBool())
+
+	// Volumes mounted out-of-band are only reported on import.
+	data.Volumes = types.ListNull(models.InstanceVolumeModelType)
+	readInstanceVolumes(context.Background(), apiVols, &data, false)
+	assert.True(t, data.Volumes.IsNull())
+
+	readInstanceVolumes(context.Background(), apiVols, &data, true)
+	assert.Len(t, data.Volumes.Elements(), 2)
+}
+
+func TestInstanceStateSatisfies(t *testing.T) {
+	tests := []struct {
+		desired string
+		actual  platform.InstanceState
+		want    bool
+	}{
+		{desiredStateRunning, platform.InstanceStateRunning, true},
+		{desiredStateRunning, platform.InstanceStateStarting, true},
+		{desiredStateRunning, platform.InstanceStateStandby, true},
+		{desiredStateRunning, platform.InstanceStateStopped, false},
+		{desiredStateStandby, platform.InstanceStateRunning, true},
+		{desiredStateStandby, platform.InstanceStateStopped, false},
+		{desiredStateStopped, platform.InstanceStateStopping, true},
+		{desiredStateStopped, platform.InstanceStateStopped, true},
+		{desiredStateStopped, platform.InstanceStateRunning, false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, instanceStateSatisfies(tt.desired, tt.actual), "desired=%s actual=%s", tt.desired, tt.actual)
+	}
+}
+
+func TestDesiredStateOf(t *testing.T) {
+	assert.Equal(t, desiredStateRunning, desiredStateOf(platform.InstanceStateStarting))
+	assert.Equal(t, desiredStateStandby, desiredStateOf(platform.InstanceStateStandby))
+	assert.Equal(t, desiredStateStopped, desiredStateOf(platform.InstanceStateDraining))
+}
+
+func TestInstanceTargetStates(t *testing.T) {
+	running := []platform.InstanceState{platform.InstanceStateRunning, platform.InstanceStateStandby}
+	stopped := []platform.InstanceState{platform.InstanceStateStopped}
+
+	tests := []struct {
+		autostart types.Bool
+		desired   types.String
+		want      []platform.InstanceState
+	}{
+		{types.BoolValue(true), types.StringNull(), running},
+		{types.BoolValue(false), types.StringNull(), stopped},
+		{types.BoolNull(), types.StringValue(desiredStateStopped), stopped},
+		{types.BoolNull(), types.StringValue(desiredStateStandby), running},
+	}
+
+	for _, tt := range tests {
+		data := &InstanceResourceModel{Autostart: tt.autostart, DesiredState: tt.desired}
+		assert.Equal(t, tt.want, instanceTargetStates(data))
+	}
+}
+
+func TestCanRollout(t *testing.T) {
+	volumes := types.ListValueMust(models.InstanceVolumeModelType, []attr.Value{
+		types.ObjectValueMust(models.InstanceVolumeModelType.AttrTypes, map[string]attr.Value{
+			"uuid":      types.StringNull(),
+			"name":      types.StringValue("data"),
+			"at":        types.StringValue("/data"),
+			"read_only": types.BoolNull(),
+		}),
+	})
+	noVolumes := types.ListNull(models.InstanceVolumeModelType)
+
+	assert.True(t, canRollout(types.StringValue("sg-uuid"), noVolumes, types.StringNull()))
+	assert.False(t, canRollout(types.StringValue("sg-uuid"), noVolumes, types.StringValue("my-instance")))
+	assert.False(t, canRollout(types.StringValue("sg-uuid"), volumes, types.StringNull()))
+	assert.False(t, canRollout(types.StringValue("sg-uuid"), types.ListUnknown(models.InstanceVolumeModelType), types.StringNull()))
+	assert.False(t, canRollout(types.StringNull(), noVolumes, types.StringNull()))
+	assert.False(t, canRollout(types.StringUnknown(), noVolumes, types.StringNull()))
+}
+
+func TestImageDigest(t *testing.T) {
+	assert.Equal(t, "sha256:18a381f0062", imageDigest("myimage@sha256:18a381f0062"))
+	assert.Equal(t, "sha256:18a381f0062", imageDigest("myuser.unikraft.io/myimage:latest@sha256:18a381f0062"))
+	assert.Empty(t, imageDigest("myimage:latest"))
+}
+
+func TestUUIDPattern(t *testing.T) {
+	assert.True(t, uuidPattern.MatchString("01234567-89ab-cdef-0123-456789ABCDEF"))
+	assert.False(t, uuidPattern.MatchString("my-instance"))
+	assert.False(t, uuidPattern.MatchString("01234567-89ab-cdef-0123-456789abcdef-suffix"))
+}
+
+func TestInstanceCreateRequest(t *testing.T) {
+	data := &InstanceResourceModel{
+		Image:            types.StringValue("nginx:latest"),
+		Name:             types.StringValue("my-instance"),
+		MemoryMB:         types.Int64Value(128),
+		RestartPolicy:    types.StringValue(restartPolicyOnFailure),
+		DesiredState:     types.StringValue(desiredStateStopped),
+		Args:             types.ListValueMust(types.StringType, []attr.Value{types.StringValue("-v")}),
+		Env:              types.MapNull(types.StringType),
+		SecretEnv:        types.MapNull(types.StringType),
+		ServiceGroupUUID: types.StringValue("sg-uuid"),
+		Volumes:          types.ListNull(models.InstanceVolumeModelType),
+	}
+
+	in, diags := instanceCreateRequest(context.Background(), data)
+
+	assert.False(t, diags.HasError())
+	assert.Equal(t, "nginx:latest", in.Image)
+	assert.Equal(t, "my-instance", *in.Name)
+	assert.Equal(t, platform.CreateInstanceRequestRestartPolicyOn_failure, *in.RestartPolicy)
+	assert.Equal(t, int64(128), *in.MemoryMb)
+	assert.False(t, *in.Autostart)
+	assert.Equal(t, []string{"-v"}, in.Args)
+	assert.Nil(t, in.Env)
+	if assert.NotNil(t, in.ServiceGroup) {
+		assert.Equal(t, "sg-uuid", *in.ServiceGroup.Uuid)
+	}
+}
+
+func TestDescribeStop(t *testing.T) {
+	exitCode := uint32(1)
+	reason := platform.StopReasonApplication | platform.StopReasonPlatform
+	ins := &platform.Instance{ExitCode: &exitCode, StopReason: &reason}
+
+	assert.True(t, stoppedUnexpectedly(ins))
+	assert.Contains(t, describeStop(ins), "exit code 1")
+
+	reason = platform.StopReasonUserShutdownComplete
+	assert.False(t, stoppedUnexpectedly(ins))
+	assert.NotContains(t, describeStopCause(ins), "exit code")
+}
+
+func TestWaitInstanceState(t *testing.T) {
+	mockClient := new(providerMock.PlatformClient)
+	mockClient.On("GetInstanceByUUID", mock.Anything, "ins-uuid", true).Return(testInstance("ins-uuid", platform.InstanceStateStarting), nil).Once()
+	mockClient.On("GetInstanceByUUID", mock.Anything, "ins-uuid", true).Return(testInstance("ins-uuid", platform.InstanceStateRunning), nil).Once()
+
+	err := waitInstanceState(context.Background(), mockClient.Client(), "ins-uuid", time.Minute, instanceRunningStates...)
+
+	assert.NoError(t, err)
+	mockClient.AssertExpectations(t)
+}
+
+func TestWaitInstanceState_Crashed(t *testing.T) {
+	mockClient := new(providerMock.PlatformClient)
+	mockClient.On("GetInstanceByUUID", mock.Anything, "ins-uuid", true).Return(crashedInstance("ins-uuid"), nil).Once()
+
+	// The instance is not restarted, so waiting stops right away.
+	err := waitInstanceState(context.Background(), mockClient.Client(), "ins-uuid", time.Minute, instanceRunningStates...)
+
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "stopped unexpectedly")
+	}
+	mockClient.AssertExpectations(t)
+}
+
+func TestRestartsOnFailure(t *testing.T) {
+	ins := &platform.Instance{}
+	assert.False(t, restartsOnFailure(ins))
+
+	policy := platform.InstanceRestartPolicyNever
+	ins.RestartPolicy = &policy
+	assert.False(t, restartsOnFailure(ins))
+
+	policy = platform.InstanceRestartPolicyOn_failure
+	assert.True(t, restartsOnFailure(ins))
 }
 
 func TestInstanceResourceModel_Basic(t *testing.T) {
@@ -0,0 +1,117 @@
+// Copyright (c) Unikraft GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/unikraft-cloud/terraform-provider-unikraft-cloud/internal/provider/clients"
+)
+
+// metroAttribute returns the schema of the metro a resource is located in. It
+// is shared by all resources.
+func metroAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional: true,
+		Computed: true,
+		MarkdownDescription: "Metro the resource is located in. Defaults to the metro of the provider. " +
+			"Changing it, or the metro of the provider when it is not set, forces a new resource to be created.",
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+			stringplanmodifier.RequiresReplaceIf(
+				metroRequiresReplace,
+				"Changing the metro forces a new resource to be created.",
+				"Changing the metro forces a new resource to be created.",
+			),
+		},
+	}
+}
+
+// metroRequiresReplace requires the resource to be replaced when the metro set
+// in the configuration differs from the one in the state. Resources created
+// before the metro attribute existed have no metro in their state, and are
+// located in the metro of the provider, so they are kept. Resources which do
+// not set a metro are handled by modifyPlanMetro, since the metro of the
+// provider is not known to plan modifiers.
+func metroRequiresReplace(_ context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	resp.RequiresReplace = !req.StateValue.IsNull() && !req.ConfigValue.IsNull() && !req.ConfigValue.Equal(req.StateValue)
+}
+
+// modifyPlanMetro plans the metro of the provider for a resource which does
+// not set a metro in its configuration, and requires the resource to be
+// replaced when it is located in another metro, e.g. after the metro of the
+// provider was changed. It is called from the ModifyPlan method of all
+// resources.
+func modifyPlanMetro(ctx context.Context, pool *clients.Pool, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// The provider may not be configured yet, e.g. during validation, and
+	// there is nothing to replace on creation and destruction.
+	if pool == nil || req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var config, state types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("metro"), &config)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("metro"), &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Resources created before the metro attribute existed are kept.
+	if !config.IsNull() || state.IsNull() || state.IsUnknown() {
+		return
+	}
+
+	metro := pool.Resolve("")
+	if metro == "" || metro == state.ValueString() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("metro"), metro)...)
+	resp.RequiresReplace = append(resp.RequiresReplace, path.Root("metro"))
+}
+
+// parseImportID splits an import identifier made of n parts separated by
+// slashes, optionally prefixed by the metro the resource is located in, e.g.
+// "[<metro>/]<uuid>".
+func parseImportID(id string, n int) (metro string, parts []string, ok bool) {
+	parts = strings.Split(id, "/")
+	if slices.Contains(parts, "") {
+		return "", nil, false
+	}
+
+	switch len(parts) {
+	case n:
+		return "", parts, true
+	case n + 1:
+		return parts[0], parts[1:], true
+	}
+	return "", nil, false
+}
+
+// importStateWithMetro imports a resource by the identifier stored in the
+// attribute at p, optionally prefixed by the metro the resource is located in.
+func importStateWithMetro(ctx context.Context, p path.Path, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	metro, parts, ok := parseImportID(req.ID, 1)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected an import ID of the form [<metro>/]<id>, got: %q", req.ID),
+		)
+		return
+	}
+
+	if metro != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("metro"), metro)...)
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, p, parts[0])...)
+}
@@ -0,0 +1,142 @@
+// Copyright (c) Unikraft GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	providerMock "github.com/unikraft-cloud/terraform-provider-unikraft-cloud/internal/provider/mock"
+)
+
+// planMetro runs the plan modifiers of the metro attribute of a volume with
+// the given metro in the state and in the configuration.
+func planMetro(t *testing.T, state, config types.String) *planmodifier.StringResponse {
+	t.Helper()
+
+	r := NewVolumeResource()
+	stateData := testState(t, r)
+	require.False(t, stateData.SetAttribute(context.Background(), path.Root("metro"), state).HasError())
+	plan := testPlan(t, r, map[string]attr.Value{"metro": config})
+
+	planValue := config
+	if config.IsNull() {
+		planValue = types.StringUnknown()
+	}
+
+	resp := &planmodifier.StringResponse{PlanValue: planValue}
+	for _, m := range metroAttribute().PlanModifiers {
+		req := planmodifier.StringRequest{
+			Path:        path.Root("metro"),
+			Config:      tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw},
+			ConfigValue: config,
+			Plan:        plan,
+			PlanValue:   resp.PlanValue,
+			State:       stateData,
+			StateValue:  state,
+		}
+		m.PlanModifyString(context.Background(), req, resp)
+		require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	}
+	return resp
+}
+
+func TestMetroAttribute_RequiresReplace(t *testing.T) {
+	resp := planMetro(t, types.StringValue("fra0"), types.StringValue("sfo0"))
+	assert.True(t, resp.RequiresReplace, "the metro changed")
+
+	resp = planMetro(t, types.StringValue("fra0"), types.StringValue("fra0"))
+	assert.False(t, resp.RequiresReplace)
+
+	resp = planMetro(t, types.StringValue("fra0"), types.StringNull())
+	assert.False(t, resp.RequiresReplace, "the metro of the provider is used")
+	assert.Equal(t, "fra0", resp.PlanValue.ValueString())
+}
+
+func TestMetroAttribute_UpgradeWithoutMetro(t *testing.T) {
+	// States written before the metro attribute existed have no metro.
+	resp := planMetro(t, types.StringNull(), types.StringNull())
+	assert.False(t, resp.RequiresReplace)
+
+	resp = planMetro(t, types.StringNull(), types.StringValue("fra0"))
+	assert.False(t, resp.RequiresReplace)
+}
+
+// modifyPlanMetroOf plans a volume located in the given metro whose
+// configuration sets the given metro, with fra0 as the metro of the provider.
+func modifyPlanMetroOf(t *testing.T, state, config types.String) *resource.ModifyPlanResponse {
+	t.Helper()
+
+	r := &VolumeResource{clients: testPool(new(providerMock.PlatformClient))}
+	stateData := testState(t, r)
+	require.False(t, stateData.SetAttribute(context.Background(), path.Root("metro"), state).HasError())
+	plan := testPlan(t, r, map[string]attr.Value{"metro": state})
+	if !config.IsNull() {
+		require.False(t, plan.SetAttribute(context.Background(), path.Root("metro"), config).HasError())
+	}
+	configData := testPlan(t, r, map[string]attr.Value{"metro": config})
+
+	resp := &resource.ModifyPlanResponse{Plan: plan}
+	r.ModifyPlan(context.Background(), resource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: configData.Schema, Raw: configData.Raw},
+		Plan:   plan,
+		State:  stateData,
+	}, resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	return resp
+}
+
+func TestModifyPlanMetro(t *testing.T) {
+	var metro types.String
+
+	// The metro of the provider changed from sfo0 to fra0.
+	resp := modifyPlanMetroOf(t, types.StringValue("sfo0"), types.StringNull())
+	assert.Equal(t, path.Paths{path.Root("metro")}, resp.RequiresReplace)
+	require.False(t, resp.Plan.GetAttribute(context.Background(), path.Root("metro"), &metro).HasError())
+	assert.Equal(t, "fra0", metro.ValueString())
+
+	resp = modifyPlanMetroOf(t, types.StringValue("fra0"), types.StringNull())
+	assert.Empty(t, resp.RequiresReplace)
+
+	// Metros set in the configuration are left to the plan modifiers.
+	resp = modifyPlanMetroOf(t, types.StringValue("sfo0"), types.StringValue("sfo0"))
+	assert.Empty(t, resp.RequiresReplace)
+
+	// States written before the metro attribute existed have no metro.
+	resp = modifyPlanMetroOf(t, types.StringNull(), types.StringNull())
+	assert.Empty(t, resp.RequiresReplace)
+}
+
+func TestParseImportID(t *testing.T) {
+	metro, parts, ok := parseImportID("vol-uuid", 1)
+	assert.True(t, ok)
+	assert.Empty(t, metro)
+	assert.Equal(t, []string{"vol-uuid"}, parts)
+
+	metro, parts, ok = parseImportID("sfo0/vol-uuid", 1)
+	assert.True(t, ok)
+	assert.Equal(t, "sfo0", metro)
+	assert.Equal(t, []string{"vol-uuid"}, parts)
+
+	metro, parts, ok = parseImportID("sfo0/vol-uuid/ins-uuid", 2)
+	assert.True(t, ok)
+	assert.Equal(t, "sfo0", metro)
+	assert.Equal(t, []string{"vol-uuid", "ins-uuid"}, parts)
+
+	_, _, ok = parseImportID("a/b/c", 1)
+	assert.False(t, ok)
+	_, _, ok = parseImportID("/vol-uuid", 1)
+	assert.False(t, ok)
+	_, _, ok = parseImportID("", 1)
+	assert.False(t, ok)
+}
@@ -0,0 +1,65 @@
+// Copyright (c) Unikraft GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"unikraft.com/cloud/sdk/platform"
+)
+
+// getQuotaLimits fetches the resource limits of the account the client is
+// authenticated as.
+func getQuotaLimits(ctx context.Context, client platform.Client) (*platform.QuotasLimits, error) {
+	userResp, err := client.GetUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if userResp == nil || userResp.Data == nil || len(userResp.Data.Quotas) == 0 || userResp.Data.Quotas[0].Limits == nil {
+		return nil, errors.New("empty response from get user API")
+	}
+
+	return userResp.Data.Quotas[0].Limits, nil
+}
+
+// checkQuotaLimit returns an error diagnostic for the attribute at p when its
+// value lies outside of the [lower, upper] range allowed by the quota of the
+// account. Null and unknown values, as well as bounds which are not reported
+// by the platform, are not checked.
+func checkQuotaLimit(p path.Path, v types.Int64, unit string, lower, upper *int64) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if v.IsNull() || v.IsUnknown() {
+		return diags
+	}
+	val := v.ValueInt64()
+
+	if (lower == nil || val >= *lower) && (upper == nil || val <= *upper) {
+		return diags
+	}
+
+	var limit string
+	switch {
+	case lower != nil && upper != nil:
+		limit = fmt.Sprintf("between %d and %d%s", *lower, *upper, unit)
+	case lower != nil:
+		limit = fmt.Sprintf("at least %d%s", *lower, unit)
+	default:
+		limit = fmt.Sprintf("at most %d%s", *upper, unit)
+	}
+
+	diags.AddAttributeError(
+		p,
+		"Quota Exceeded",
+		fmt.Sprintf("The quota of the account requires a value %s, got: %d.", limit, val),
+	)
+	return diags
+}
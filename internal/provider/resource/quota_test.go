@@ -0,0 +1,40 @@
+// Copyright (c) Unikraft GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckQuotaLimit(t *testing.T) {
+	lower, upper := int64(16), int64(4096)
+	p := path.Root("memory_mb")
+
+	assert.False(t, checkQuotaLimit(p, types.Int64Value(512), " MB", &lower, &upper).HasError())
+	assert.False(t, checkQuotaLimit(p, types.Int64Unknown(), " MB", &lower, &upper).HasError())
+	assert.False(t, checkQuotaLimit(p, types.Int64Null(), " MB", &lower, &upper).HasError())
+	assert.False(t, checkQuotaLimit(p, types.Int64Value(1<<20), " MB", &lower, nil).HasError())
+
+	diags := checkQuotaLimit(p, types.Int64Value(8192), " MB", &lower, &upper)
+	if assert.True(t, diags.HasError()) {
+		assert.Contains(t, diags.Errors()[0].Detail(), "between 16 and 4096 MB, got: 8192")
+	}
+
+	diags = checkQuotaLimit(p, types.Int64Value(8), " MB", &lower, nil)
+	if assert.True(t, diags.HasError()) {
+		assert.Contains(t, diags.Errors()[0].Detail(), "at least 16 MB")
+	}
+
+	diags = checkQuotaLimit(path.Root("vcpus"), types.Int64Value(16), " vCPUs", nil, &lower)
+	assert.False(t, diags.HasError())
+	upper = 8
+	diags = checkQuotaLimit(path.Root("vcpus"), types.Int64Value(16), " vCPUs", nil, &upper)
+	if assert.True(t, diags.HasError()) {
+		assert.Contains(t, diags.Errors()[0].Detail(), "at most 8 vCPUs")
+	}
+}
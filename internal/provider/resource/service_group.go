@@ -0,0 +1,719 @@
+// Copyright (c) Unikraft GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/unikraft-cloud/terraform-provider-unikraft-cloud/internal/provider/clients"
+	models "github.com/unikraft-cloud/terraform-provider-unikraft-cloud/internal/provider/model"
+
+	"unikraft.com/cloud/sdk/platform"
+)
+
+func NewServiceGroupResource() resource.Resource {
+	return &ServiceGroupResource{}
+}
+
+// ServiceGroupResource defines the resource implementation.
+type ServiceGroupResource struct {
+	clients *clients.Pool
+}
+
+// Ensure ServiceGroupResource satisfies various resource interfaces.
+var (
+	_ resource.Resource                = &ServiceGroupResource{}
+	_ resource.ResourceWithImportState = &ServiceGroupResource{}
+	_ resource.ResourceWithModifyPlan  = &ServiceGroupResource{}
+)
+
+// ServiceGroupResourceModel describes the resource data model.
+type ServiceGroupResourceModel struct {
+	Metro types.String `tfsdk:"metro"`
+
+	models.SvcGrpModel
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata implements resource.Resource.
+func (r *ServiceGroupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_service_group"
+}
+
+// Schema implements resource.Resource.
+func (r *ServiceGroupResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Allows the creation of Unikraft Cloud service groups, which expose one or more " +
+			"instances behind a load-balanced public endpoint.",
+
+		Attributes: map[string]schema.Attribute{
+			"metro": metroAttribute(),
+			"uuid": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier of the service group.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The name of the service group. If not specified, a random name is generated.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"services": servicesAttribute(),
+			"domains":  domainsAttribute(),
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// Configure implements resource.Resource.
+func (r *ServiceGroupResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	pool, ok := req.ProviderData.(*clients.Pool)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *clients.Pool, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.clients = pool
+}
+
+// Create implements resource.Resource.
+func (r *ServiceGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ServiceGroupResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sgServices, diags := platformServices(ctx, data.Services)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	in := platform.CreateServiceGroupRequest{
+		Services: sgServices,
+		Domains:  createServiceGroupDomains(data.Domains),
+	}
+
+	if !data.Name.IsNull() && !data.Name.IsUnknown() {
+		in.Name = data.Name.ValueStringPointer()
+	}
+
+	client := r.clients.Client(&data.Metro)
+
+	sgResp, err := client.CreateServiceGroup(ctx, in)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Failed to create service group, got error: %v", err),
+		)
+		return
+	}
+
+	if sgResp == nil || sgResp.Data == nil || len(sgResp.Data.ServiceGroups) == 0 {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			"Empty response from create service group API",
+		)
+		return
+	}
+	sg := sgResp.Data.ServiceGroups[0]
+
+	if sg.Uuid == nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			"Service group UUID not returned by API",
+		)
+		return
+	}
+
+	// Get full service group state
+	resp.Diagnostics.Append(readServiceGroup(ctx, client, *sg.Uuid, &data.SvcGrpModel)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read implements resource.Resource.
+func (r *ServiceGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ServiceGroupResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.clients.Client(&data.Metro)
+
+	diags := readServiceGroup(ctx, client, data.UUID.ValueString(), &data.SvcGrpModel)
+	if hasNotFound(diags) {
+		// The service group was deleted out-of-band, let Terraform recreate it.
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update implements resource.Resource.
+func (r *ServiceGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan ServiceGroupResourceModel
+	var state ServiceGroupResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.clients.Client(&plan.Metro)
+
+	resp.Diagnostics.Append(updateServiceGroup(ctx, client, state.UUID.ValueString(), &plan.SvcGrpModel, &state.SvcGrpModel)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Re-read full state after update
+	data := plan
+	resp.Diagnostics.Append(readServiceGroup(ctx, client, state.UUID.ValueString(), &data.SvcGrpModel)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete implements resource.Resource.
+func (r *ServiceGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ServiceGroupResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.clients.Client(&data.Metro)
+
+	_, err := client.DeleteServiceGroupByUUID(ctx, data.UUID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Failed to delete service group, got error: %v", err),
+		)
+		return
+	}
+
+	err = waitFor(ctx, deleteTimeout, func(ctx context.Context) (bool, error) {
+		sgResp, err := client.GetServiceGroupByUUID(ctx, data.UUID.ValueString(), false)
+		if isNotFound(err) {
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		return sgResp == nil || sgResp.Data == nil || len(sgResp.Data.ServiceGroups) == 0, nil
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Service group %s was not deleted, got error: %v", data.UUID.ValueString(), err),
+		)
+		return
+	}
+}
+
+// ModifyPlan implements resource.ResourceWithModifyPlan.
+func (r *ServiceGroupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPlanMetro(ctx, r.clients, req, resp)
+}
+
+// ImportState implements resource.ResourceWithImportState.
+func (r *ServiceGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importStateWithMetro(ctx, path.Root("uuid"), req, resp)
+}
+
+// createServiceGroupDomains converts the domains of a service group's data
+// model to domains of a create service group request.
+func createServiceGroupDomains(domains []models.DomainModel) []platform.CreateServiceGroupRequestDomain {
+	if len(domains) == 0 {
+		return nil
+	}
+
+	out := make([]platform.CreateServiceGroupRequestDomain, len(domains))
+	for i, d := range domains {
+		out[i].Name = d.Name.ValueString()
+		if ref := certificateRef(d.Certificate); ref != nil {
+			out[i].Certificate = &platform.CreateServiceGroupRequestDomainCertificate{}
+			if ref.Uuid != nil {
+				out[i].Certificate.Uuid = *ref.Uuid
+			}
+			if ref.Name != nil {
+				out[i].Certificate.Name = *ref.Name
+			}
+		}
+	}
+	return out
+}
+
+// servicesAttribute returns the schema of the services exposed by a service
+// group. It is shared by the service group and instance resources.
+func servicesAttribute() schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		Required: true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"port": schema.Int64Attribute{
+					Required: true,
+					Validators: []validator.Int64{
+						int64validator.Between(1, math.MaxUint16),
+					},
+				},
+				"destination_port": schema.Int64Attribute{
+					Optional: true,
+					Computed: true,
+					Validators: []validator.Int64{
+						int64validator.Between(1, math.MaxUint16),
+					},
+					PlanModifiers: []planmodifier.Int64{
+						int64planmodifier.UseStateForUnknown(),
+					},
+				},
+				"handlers": schema.SetAttribute{
+					ElementType: types.StringType,
+					Optional:    true,
+					Computed:    true,
+					PlanModifiers: []planmodifier.Set{
+						setplanmodifier.UseStateForUnknown(),
+					},
+				},
+			},
+		},
+	}
+}
+
+// domainsAttribute returns the schema of the domains of a service group. It is
+// shared by the service group and instance resources.
+func domainsAttribute() schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		Optional: true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"name": schema.StringAttribute{
+					Required: true,
+					MarkdownDescription: "Publicly accessible domain name. Names ending with a period (e.g. `example.com.`) " +
+						"are fully qualified, other names become a subdomain of the metro.",
+				},
+				"fqdn": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "Fully qualified domain name assigned by the platform.",
+				},
+				"certificate": schema.SingleNestedAttribute{
+					Optional: true,
+					MarkdownDescription: "Existing certificate to serve the domain with, referenced by `uuid` or `name`. " +
+						"When not set, a certificate is issued automatically by the platform.",
+					Attributes: map[string]schema.Attribute{
+						"uuid": schema.StringAttribute{
+							Optional: true,
+							Computed: true,
+							Validators: []validator.String{
+								stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("name")),
+							},
+						},
+						"name": schema.StringAttribute{
+							Optional: true,
+							Computed: true,
+						},
+						"state": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "State of the certificate (`pending`, `valid` or `error`).",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// updateServiceGroup applies the changes of services and domains between the
+// prior state and the plan of a service group. Both can be changed without
+// interrupting the instances of the service group.
+func updateServiceGroup(ctx context.Context, client platform.Client, uuid string, plan, state *models.SvcGrpModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if servicesChanged(plan.Services, state.Services) {
+		sgServices, d := platformServices(ctx, plan.Services)
+		diags.Append(d...)
+		if diags.HasError() {
+			return diags
+		}
+
+		val := any(sgServices)
+		_, err := client.UpdateServiceGroupByUUID(ctx, uuid, platform.UpdateServiceGroupByUUIDRequestBody{
+			Prop:  platform.UpdateServiceGroupByUUIDRequestBodyPropServices,
+			Op:    platform.UpdateServiceGroupByUUIDRequestBodyOpSet,
+			Value: &val,
+		})
+		if err != nil {
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Failed to update service group services, got error: %v", err),
+			)
+			return diags
+		}
+	}
+
+	if domainsChanged(plan.Domains, state.Domains) {
+		val := any(platformDomains(plan.Domains))
+		_, err := client.UpdateServiceGroupByUUID(ctx, uuid, platform.UpdateServiceGroupByUUIDRequestBody{
+			Prop:  platform.UpdateServiceGroupByUUIDRequestBodyPropDomains,
+			Op:    platform.UpdateServiceGroupByUUIDRequestBodyOpSet,
+			Value: &val,
+		})
+		if err != nil {
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Failed to update service group domains, got error: %v", err),
+			)
+			return diags
+		}
+	}
+
+	return diags
+}
+
+// readServiceGroup fetches the current state of a service group and rebuilds
+// the services and domains of its data model from it, so that changes made
+// outside of Terraform are detected. Services are matched by port and domains
+// by name, since the API may return them in a different order than they were
+// declared in.
+func readServiceGroup(ctx context.Context, client platform.Client, sgUUID string, sg *models.SvcGrpModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	sgResp, err := client.GetServiceGroupByUUID(ctx, sgUUID, true)
+	if isNotFound(err) {
+		diags.Append(newNotFoundDiagnostic("service group", sgUUID))
+		return diags
+	}
+	if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Failed to get service group state, got error: %v", err),
+		)
+		return diags
+	}
+
+	if sgResp == nil || sgResp.Data == nil || len(sgResp.Data.ServiceGroups) == 0 {
+		diags.AddError(
+			"Client Error",
+			"Empty response from get service group API",
+		)
+		return diags
+	}
+	apiSG := sgResp.Data.ServiceGroups[0]
+
+	sg.UUID = types.StringValue(sgUUID)
+	if apiSG.Name != nil {
+		sg.Name = types.StringValue(*apiSG.Name)
+	}
+
+	diags.Append(readServices(ctx, apiSG.Services, sg)...)
+	diags.Append(readDomains(apiSG.Domains, sg)...)
+
+	return diags
+}
+
+// readServices rebuilds the services of a service group's data model from the
+// services reported by the platform. Services keep the order in which they
+// were declared, and services which are not declared (e.g. after "terraform
+// import") are appended in the order of the API.
+func readServices(ctx context.Context, apiServices []platform.Service, sg *models.SvcGrpModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	ordered := make([]platform.Service, 0, len(apiServices))
+	seen := make([]bool, len(apiServices))
+	for _, svc := range sg.Services {
+		for j, apiSvc := range apiServices {
+			if !seen[j] && int64(apiSvc.Port) == svc.Port.ValueInt64() {
+				ordered = append(ordered, apiSvc)
+				seen[j] = true
+				break
+			}
+		}
+	}
+	for j, apiSvc := range apiServices {
+		if !seen[j] {
+			ordered = append(ordered, apiSvc)
+		}
+	}
+
+	sg.Services = make([]models.SvcModel, len(ordered))
+	for i, apiSvc := range ordered {
+		svc := &sg.Services[i]
+		svc.Port = types.Int64Value(int64(apiSvc.Port))
+
+		if apiSvc.DestinationPort != nil {
+			svc.DestinationPort = types.Int64Value(int64(*apiSvc.DestinationPort))
+		} else {
+			svc.DestinationPort = svc.Port
+		}
+
+		handlers := make([]string, len(apiSvc.Handlers))
+		for j, h := range apiSvc.Handlers {
+			handlers[j] = string(h)
+		}
+		var d diag.Diagnostics
+		svc.Handlers, d = types.SetValueFrom(ctx, types.StringType, handlers)
+		diags.Append(d...)
+	}
+
+	return diags
+}
+
+// platformServices converts the services of a service group model to their
+// API representation.
+func platformServices(ctx context.Context, svcs []models.SvcModel) ([]platform.Service, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	sgServices := make([]platform.Service, len(svcs))
+	for i, svc := range svcs {
+		port := uint32(svc.Port.ValueInt64())
+		sgServices[i].Port = port
+
+		// New SDK properly handles optional destination port with pointer
+		if !svc.DestinationPort.IsUnknown() && !svc.DestinationPort.IsNull() {
+			destPort := uint32(svc.DestinationPort.ValueInt64())
+			sgServices[i].DestinationPort = &destPort
+		}
+
+		if !svc.Handlers.IsUnknown() {
+			handlVals := make([]types.String, 0, len(svc.Handlers.Elements()))
+			diags.Append(svc.Handlers.ElementsAs(ctx, &handlVals, false)...)
+			for _, v := range handlVals {
+				sgServices[i].Handlers = append(sgServices[i].Handlers, platform.ServiceHandlers(v.ValueString()))
+			}
+		}
+	}
+
+	return sgServices, diags
+}
+
+// servicesChanged reports whether the planned services differ from the ones
+// recorded in the prior state.
+func servicesChanged(plan, state []models.SvcModel) bool {
+	if len(plan) != len(state) {
+		return true
+	}
+
+	for i := range plan {
+		if !plan[i].Port.Equal(state[i].Port) ||
+			!plan[i].DestinationPort.Equal(state[i].DestinationPort) ||
+			!plan[i].Handlers.Equal(state[i].Handlers) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// platformDomains converts the domains of a service group's data model to
+// domains of a create or update request.
+func platformDomains(domains []models.DomainModel) []platform.CreateInstanceRequestDomain {
+	if len(domains) == 0 {
+		return nil
+	}
+
+	out := make([]platform.CreateInstanceRequestDomain, len(domains))
+	for i, d := range domains {
+		out[i].Name = d.Name.ValueString()
+		out[i].Certificate = certificateRef(d.Certificate)
+	}
+	return out
+}
+
+// certificateRef returns a reference to the certificate of a domain by UUID
+// or name, or nil if the domain does not reference a certificate.
+func certificateRef(crt types.Object) *platform.NameOrUUID {
+	if crt.IsNull() || crt.IsUnknown() {
+		return nil
+	}
+
+	var ref platform.NameOrUUID
+	attrs := crt.Attributes()
+	if uuid, ok := attrs["uuid"].(types.String); ok && !uuid.IsNull() && !uuid.IsUnknown() {
+		ref.Uuid = uuid.ValueStringPointer()
+	} else if name, ok := attrs["name"].(types.String); ok && !name.IsNull() && !name.IsUnknown() {
+		ref.Name = name.ValueStringPointer()
+	} else {
+		return nil
+	}
+	return &ref
+}
+
+// domainsChanged reports whether the planned domains differ from the ones
+// recorded in the prior state, including a certificate reference that was
+// added or removed.
+func domainsChanged(plan, state []models.DomainModel) bool {
+	if len(plan) != len(state) {
+		return true
+	}
+
+	for i := range plan {
+		if !plan[i].Name.Equal(state[i].Name) {
+			return true
+		}
+
+		ref := certificateRef(plan[i].Certificate)
+		prev := certificateRef(state[i].Certificate)
+		if ref == nil || prev == nil {
+			if (ref == nil) != (prev == nil) {
+				return true
+			}
+			continue
+		}
+		stateAttrs := state[i].Certificate.Attributes()
+		if ref.Uuid != nil && !stateAttrs["uuid"].Equal(types.StringPointerValue(ref.Uuid)) {
+			return true
+		}
+		if ref.Name != nil && !stateAttrs["name"].Equal(types.StringPointerValue(ref.Name)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// domainMatches reports whether the given FQDN was assigned by the platform to
+// a domain with the given name.
+func domainMatches(name, fqdn string) bool {
+	name = strings.TrimSuffix(name, ".")
+	fqdn = strings.TrimSuffix(fqdn, ".")
+	return fqdn == name || strings.HasPrefix(fqdn, name+".")
+}
+
+// readDomains rebuilds the domains of a service group's data model from the
+// domains reported by the platform. Declared domains keep their name and order
+// and are dropped when the platform no longer reports them. Domains which are
+// not declared (e.g. after "terraform import") are appended under their fully
+// qualified name. The certificate of a domain is only reported when the domain
+// references one, since it is otherwise issued by the platform.
+func readDomains(apiDomains []platform.Domain, sg *models.SvcGrpModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// Retain an empty list of domains, while keeping them null when unset.
+	out := sg.Domains[:0:0]
+	seen := make([]bool, len(apiDomains))
+	for _, dom := range sg.Domains {
+		for j := range apiDomains {
+			if seen[j] || apiDomains[j].Fqdn == nil || !domainMatches(dom.Name.ValueString(), *apiDomains[j].Fqdn) {
+				continue
+			}
+			seen[j] = true
+
+			d := readDomain(&apiDomains[j], &dom)
+			diags.Append(d...)
+			out = append(out, dom)
+			break
+		}
+	}
+
+	for j := range apiDomains {
+		if seen[j] || apiDomains[j].Fqdn == nil {
+			continue
+		}
+
+		dom := models.DomainModel{
+			Name:        types.StringValue(strings.TrimSuffix(*apiDomains[j].Fqdn, ".") + "."),
+			Certificate: types.ObjectNull(models.DomainCertificateModelType.AttrTypes),
+		}
+		diags.Append(readDomain(&apiDomains[j], &dom)...)
+		out = append(out, dom)
+	}
+
+	sg.Domains = out
+	return diags
+}
+
+// readDomain populates the FQDN and the referenced certificate of a domain's
+// data model from the domain reported by the platform.
+func readDomain(apiDom *platform.Domain, dom *models.DomainModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	dom.FQDN = types.StringPointerValue(apiDom.Fqdn)
+
+	if dom.Certificate.IsNull() {
+		return diags
+	}
+	if apiDom.Certificate == nil {
+		dom.Certificate = types.ObjectNull(models.DomainCertificateModelType.AttrTypes)
+		return diags
+	}
+
+	state := types.StringNull()
+	if apiDom.Certificate.State != nil {
+		state = types.StringValue(string(*apiDom.Certificate.State))
+	}
+
+	dom.Certificate, diags = types.ObjectValue(models.DomainCertificateModelType.AttrTypes, map[string]attr.Value{
+		"uuid":  types.StringPointerValue(apiDom.Certificate.Uuid),
+		"name":  types.StringPointerValue(apiDom.Certificate.Name),
+		"state": state,
+	})
+	return diags
+}
@@ -0,0 +1,196 @@
+// Copyright (c) Unikraft GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	providerMock "github.com/unikraft-cloud/terraform-provider-unikraft-cloud/internal/provider/mock"
+	models "github.com/unikraft-cloud/terraform-provider-unikraft-cloud/internal/provider/model"
+
+	"unikraft.com/cloud/sdk/platform"
+)
+
+func serviceGroupResponse(sg platform.ServiceGroup) *platform.Response[platform.GetServiceGroupsResponseData] {
+	return &platform.Response[platform.GetServiceGroupsResponseData]{
+		Status: "success",
+		Data:   &platform.GetServiceGroupsResponseData{ServiceGroups: []platform.ServiceGroup{sg}},
+	}
+}
+
+func TestServiceGroupResource_ImportState(t *testing.T) {
+	sgUUID, name, fqdn := "sg-uuid", "my-sg", "app.fra0.unikraft.app"
+	destPort := uint32(8080)
+	mockClient := new(providerMock.PlatformClient)
+	mockClient.On("GetServiceGroupByUUID", mock.Anything, sgUUID, true).Return(serviceGroupResponse(platform.ServiceGroup{
+		Uuid: &sgUUID,
+		Name: &name,
+		Services: []platform.Service{{
+			Port:            443,
+			DestinationPort: &destPort,
+			Handlers:        []platform.ServiceHandlers{platform.ServiceHandlersTls},
+		}},
+		Domains: []platform.Domain{{Fqdn: &fqdn}},
+	}), nil)
+
+	r := &ServiceGroupResource{clients: testPool(mockClient)}
+	importResp := &resource.ImportStateResponse{State: testState(t, r)}
+	r.ImportState(context.Background(), resource.ImportStateRequest{ID: sgUUID}, importResp)
+	require.False(t, importResp.Diagnostics.HasError(), importResp.Diagnostics)
+
+	resp := &resource.ReadResponse{State: importResp.State}
+	r.Read(context.Background(), resource.ReadRequest{State: importResp.State}, resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var got ServiceGroupResourceModel
+	require.False(t, resp.State.Get(context.Background(), &got).HasError())
+	assert.Equal(t, name, got.Name.ValueString())
+	if assert.Len(t, got.Services, 1) {
+		assert.Equal(t, int64(443), got.Services[0].Port.ValueInt64())
+		assert.Equal(t, int64(8080), got.Services[0].DestinationPort.ValueInt64())
+	}
+	if assert.Len(t, got.Domains, 1) {
+		assert.Equal(t, fqdn+".", got.Domains[0].Name.ValueString())
+		assert.Equal(t, fqdn, got.Domains[0].FQDN.ValueString())
+		assert.True(t, got.Domains[0].Certificate.IsNull())
+	}
+}
+
+func TestReadServices(t *testing.T) {
+	destPort := uint32(8443)
+	apiServices := []platform.Service{
+		{Port: 80, Handlers: []platform.ServiceHandlers{platform.ServiceHandlersHttp}},
+		{Port: 443, DestinationPort: &destPort, Handlers: []platform.ServiceHandlers{platform.ServiceHandlersTls}},
+	}
+	sg := &models.SvcGrpModel{Services: []models.SvcModel{
+		{Port: types.Int64Value(443), DestinationPort: types.Int64Value(8080), Handlers: types.SetNull(types.StringType)},
+		{Port: types.Int64Value(8080), DestinationPort: types.Int64Value(8080), Handlers: types.SetNull(types.StringType)},
+	}}
+
+	diags := readServices(context.Background(), apiServices, sg)
+
+	assert.False(t, diags.HasError())
+	if assert.Len(t, sg.Services, 2) {
+		// Declared services keep their order and report changes made outside
+		// of Terraform, services added outside of Terraform are appended.
+		assert.Equal(t, int64(443), sg.Services[0].Port.ValueInt64())
+		assert.Equal(t, int64(8443), sg.Services[0].DestinationPort.ValueInt64())
+		assert.Equal(t, types.SetValueMust(types.StringType, []attr.Value{types.StringValue("tls")}), sg.Services[0].Handlers)
+		assert.Equal(t, int64(80), sg.Services[1].Port.ValueInt64())
+		assert.Equal(t, int64(80), sg.Services[1].DestinationPort.ValueInt64())
+	}
+}
+
+func TestServicesChanged(t *testing.T) {
+	svc := models.SvcModel{
+		Port:            types.Int64Value(443),
+		DestinationPort: types.Int64Value(8080),
+		Handlers:        types.SetValueMust(types.StringType, []attr.Value{types.StringValue("tls"), types.StringValue("http")}),
+	}
+	other := svc
+	other.DestinationPort = types.Int64Value(8443)
+
+	assert.False(t, servicesChanged([]models.SvcModel{svc}, []models.SvcModel{svc}))
+	assert.True(t, servicesChanged([]models.SvcModel{other}, []models.SvcModel{svc}))
+	assert.True(t, servicesChanged([]models.SvcModel{svc, other}, []models.SvcModel{svc}))
+}
+
+func certificateObject(uuid, name, state types.String) types.Object {
+	return types.ObjectValueMust(models.DomainCertificateModelType.AttrTypes, map[string]attr.Value{
+		"uuid":  uuid,
+		"name":  name,
+		"state": state,
+	})
+}
+
+func TestPlatformDomains(t *testing.T) {
+	domains := []models.DomainModel{
+		{Name: types.StringValue("example.com."), Certificate: certificateObject(types.StringNull(), types.StringValue("my-cert"), types.StringUnknown())},
+		{Name: types.StringValue("app"), Certificate: types.ObjectUnknown(models.DomainCertificateModelType.AttrTypes)},
+	}
+
+	out := platformDomains(domains)
+
+	assert.Len(t, out, 2)
+	assert.Equal(t, "example.com.", out[0].Name)
+	assert.Nil(t, out[0].Certificate.Uuid)
+	assert.Equal(t, "my-cert", *out[0].Certificate.Name)
+	assert.Equal(t, "app", out[1].Name)
+	assert.Nil(t, out[1].Certificate)
+}
+
+func TestDomainsChanged(t *testing.T) {
+	state := []models.DomainModel{{
+		Name:        types.StringValue("app"),
+		FQDN:        types.StringValue("app.fra0.unikraft.app"),
+		Certificate: certificateObject(types.StringValue("crt-uuid"), types.StringValue("my-cert"), types.StringValue("valid")),
+	}}
+
+	plan := []models.DomainModel{{
+		Name:        types.StringValue("app"),
+		Certificate: certificateObject(types.StringUnknown(), types.StringValue("my-cert"), types.StringUnknown()),
+	}}
+	assert.False(t, domainsChanged(plan, state))
+
+	plan[0].Certificate = certificateObject(types.StringUnknown(), types.StringValue("other-cert"), types.StringUnknown())
+	assert.True(t, domainsChanged(plan, state))
+
+	// The certificate reference was removed, let the platform issue one.
+	plan[0].Certificate = types.ObjectNull(models.DomainCertificateModelType.AttrTypes)
+	assert.True(t, domainsChanged(plan, state))
+	assert.False(t, domainsChanged(plan, plan))
+
+	plan[0].Name = types.StringValue("other")
+	assert.True(t, domainsChanged(plan, state))
+	assert.True(t, domainsChanged(nil, state))
+}
+
+func TestDomainMatches(t *testing.T) {
+	assert.True(t, domainMatches("example.com.", "example.com"))
+	assert.True(t, domainMatches("app", "app.fra0.unikraft.app"))
+	assert.False(t, domainMatches("app", "other.fra0.unikraft.app"))
+}
+
+func TestReadDomains(t *testing.T) {
+	fqdn, otherFQDN, crtUUID, crtName := "app.fra0.unikraft.app", "www.example.com", "crt-uuid", "my-cert"
+	crtState := platform.CertificateStatePending
+	apiDomains := []platform.Domain{
+		{Fqdn: &otherFQDN, Certificate: &platform.Certificate{Uuid: &crtUUID, Name: &crtName, State: &crtState}},
+		{Fqdn: &fqdn, Certificate: &platform.Certificate{Uuid: &crtUUID, Name: &crtName, State: &crtState}},
+	}
+
+	sg := &models.SvcGrpModel{Domains: []models.DomainModel{
+		{Name: types.StringValue("gone"), FQDN: types.StringValue("gone.fra0.unikraft.app"), Certificate: types.ObjectNull(models.DomainCertificateModelType.AttrTypes)},
+		{Name: types.StringValue("app"), FQDN: types.StringUnknown(), Certificate: certificateObject(types.StringUnknown(), types.StringValue(crtName), types.StringUnknown())},
+	}}
+
+	diags := readDomains(apiDomains, sg)
+
+	assert.False(t, diags.HasError())
+	if assert.Len(t, sg.Domains, 2) {
+		assert.Equal(t, "app", sg.Domains[0].Name.ValueString())
+		assert.Equal(t, fqdn, sg.Domains[0].FQDN.ValueString())
+		assert.Equal(t, certificateObject(types.StringValue(crtUUID), types.StringValue(crtName), types.StringValue("pending")), sg.Domains[0].Certificate)
+		assert.Equal(t, otherFQDN+".", sg.Domains[1].Name.ValueString())
+		assert.True(t, sg.Domains[1].Certificate.IsNull())
+	}
+}
+
+func TestReadDomains_Empty(t *testing.T) {
+	sg := &models.SvcGrpModel{Domains: []models.DomainModel{}}
+	assert.False(t, readDomains(nil, sg).HasError())
+	assert.NotNil(t, sg.Domains)
+
+	sg.Domains = nil
+	assert.False(t, readDomains(nil, sg).HasError())
+	assert.Nil(t, sg.Domains)
+}
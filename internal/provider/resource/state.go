@@ -0,0 +1,42 @@
+// Copyright (c) Unikraft GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// setPartialState saves data as the state of a resource whose object was
+// created on the platform, although the operation failed afterwards, e.g.
+// because the object did not become ready in time. Terraform records the
+// object and taints it, instead of losing track of it and creating another
+// one on the next apply. Values which are still unknown are saved as null,
+// since Terraform does not accept unknown values in the state.
+func setPartialState(ctx context.Context, state *tfsdk.State, data any) diag.Diagnostics {
+	diags := state.Set(ctx, data)
+	if diags.HasError() {
+		return diags
+	}
+
+	raw, err := tftypes.Transform(state.Raw, func(_ *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
+		if !v.IsKnown() {
+			return tftypes.NewValue(v.Type(), nil), nil
+		}
+		return v, nil
+	})
+	if err != nil {
+		diags.AddError(
+			"Internal Error",
+			"Failed to save the partial state of the resource, got error: "+err.Error(),
+		)
+		return diags
+	}
+	state.Raw = raw
+
+	return diags
+}
@@ -0,0 +1,97 @@
+// Copyright (c) Unikraft GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/unikraft-cloud/terraform-provider-unikraft-cloud/internal/provider/clients"
+	providerMock "github.com/unikraft-cloud/terraform-provider-unikraft-cloud/internal/provider/mock"
+)
+
+// testSchema returns the schema of the resource r.
+func testSchema(t *testing.T, r resource.Resource) resource.SchemaResponse {
+	t.Helper()
+
+	var resp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	return resp
+}
+
+// testPlan returns a plan of the resource r with the given attributes set and
+// all others null.
+func testPlan(t *testing.T, r resource.Resource, attrs map[string]attr.Value) tfsdk.Plan {
+	t.Helper()
+
+	s := testSchema(t, r).Schema
+	plan := tfsdk.Plan{
+		Schema: s,
+		Raw:    nullAttributes(s.Type().TerraformType(context.Background())),
+	}
+	for name, v := range attrs {
+		diags := plan.SetAttribute(context.Background(), path.Root(name), v)
+		require.False(t, diags.HasError(), diags)
+	}
+	return plan
+}
+
+// testState returns an empty state of the resource r.
+func testState(t *testing.T, r resource.Resource) tfsdk.State {
+	t.Helper()
+
+	s := testSchema(t, r).Schema
+	return tfsdk.State{
+		Schema: s,
+		Raw:    nullAttributes(s.Type().TerraformType(context.Background())),
+	}
+}
+
+// nullAttributes returns an object of the given type whose attributes are all
+// null.
+func nullAttributes(typ tftypes.Type) tftypes.Value {
+	obj := typ.(tftypes.Object)
+	vals := make(map[string]tftypes.Value, len(obj.AttributeTypes))
+	for name, attrType := range obj.AttributeTypes {
+		vals[name] = tftypes.NewValue(attrType, nil)
+	}
+	return tftypes.NewValue(obj, vals)
+}
+
+// testPool returns a client pool backed by the mock client m.
+func testPool(m *providerMock.PlatformClient) *clients.Pool {
+	return clients.NewPool(m.Client(), "fra0", "")
+}
+
+func TestSetPartialState(t *testing.T) {
+	r := NewVolumeResource()
+	state := testState(t, r)
+
+	data := VolumeResourceModel{}
+	diags := state.Get(context.Background(), &data)
+	require.False(t, diags.HasError(), diags)
+
+	data.UUID = types.StringValue("vol-uuid")
+	data.State = types.StringUnknown()
+
+	diags = setPartialState(context.Background(), &state, &data)
+	assert.False(t, diags.HasError(), diags)
+	assert.True(t, state.Raw.IsFullyKnown())
+
+	var uuid, st types.String
+	state.GetAttribute(context.Background(), path.Root("uuid"), &uuid)
+	state.GetAttribute(context.Background(), path.Root("state"), &st)
+	assert.Equal(t, "vol-uuid", uuid.ValueString())
+	assert.True(t, st.IsNull())
+}
@@ -5,8 +5,11 @@ package resource
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -15,6 +18,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/unikraft-cloud/terraform-provider-unikraft-cloud/internal/provider/clients"
 	models "github.com/unikraft-cloud/terraform-provider-unikraft-cloud/internal/provider/model"
 
 	"unikraft.com/cloud/sdk/platform"
@@ -26,17 +30,19 @@ func NewVolumeResource() resource.Resource {
 
 // VolumeResource defines the resource implementation.
 type VolumeResource struct {
-	client platform.Client
+	clients *clients.Pool
 }
 
 // Ensure VolumeResource satisfies various resource interfaces.
 var (
 	_ resource.Resource                = &VolumeResource{}
 	_ resource.ResourceWithImportState = &VolumeResource{}
+	_ resource.ResourceWithModifyPlan  = &VolumeResource{}
 )
 
 // VolumeResourceModel describes the resource data model.
 type VolumeResourceModel struct {
+	Metro      types.String `tfsdk:"metro"`
 	Name       types.String `tfsdk:"name"`
 	SizeMB     types.Int64  `tfsdk:"size_mb"`
 	UUID       types.String `tfsdk:"uuid"`
@@ -44,6 +50,8 @@ type VolumeResourceModel struct {
 	Persistent types.Bool   `tfsdk:"persistent"`
 	CreatedAt  types.String `tfsdk:"created_at"`
 	AttachedTo types.List   `tfsdk:"attached_to"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 // Metadata implements resource.Resource.
@@ -57,6 +65,7 @@ func (r *VolumeResource) Schema(ctx context.Context, req resource.SchemaRequest,
 		MarkdownDescription: "Allows the creation of Unikraft Cloud volumes.",
 
 		Attributes: map[string]schema.Attribute{
+			"metro": metroAttribute(),
 			"name": schema.StringAttribute{
 				Optional:            true,
 				Computed:            true,
@@ -103,6 +112,14 @@ func (r *VolumeResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				},
 			},
 		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -113,16 +130,16 @@ func (r *VolumeResource) Configure(ctx context.Context, req resource.ConfigureRe
 		return
 	}
 
-	client, ok := req.ProviderData.(platform.Client)
+	pool, ok := req.ProviderData.(*clients.Pool)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected platform.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *clients.Pool, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.clients = pool
 }
 
 // Create implements resource.Resource.
@@ -135,6 +152,14 @@ func (r *VolumeResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.clients.Client(&data.Metro)
+
 	in := platform.CreateVolumeRequest{
 		SizeMb: uint64(data.SizeMB.ValueInt64()),
 	}
@@ -144,7 +169,7 @@ func (r *VolumeResource) Create(ctx context.Context, req resource.CreateRequest,
 		in.Name = &name
 	}
 
-	volResp, err := r.client.CreateVolume(ctx, in)
+	volResp, err := client.CreateVolume(ctx, in)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Client Error",
@@ -175,8 +200,21 @@ func (r *VolumeResource) Create(ctx context.Context, req resource.CreateRequest,
 		data.Name = types.StringValue(*vol.Name)
 	}
 
+	if err := waitVolumeReady(ctx, client, *vol.Uuid, createTimeout); err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Volume %s did not become available, got error: %v", *vol.Uuid, err),
+		)
+		// The volume exists although it did not become available. Save what
+		// is known about it, so that Terraform taints it rather than creating
+		// another one on the next apply.
+		resp.Diagnostics.Append(readVolumeState(ctx, client, &data)...)
+		resp.Diagnostics.Append(setPartialState(ctx, &resp.State, &data)...)
+		return
+	}
+
 	// Get full volume state
-	resp.Diagnostics.Append(r.readVolumeState(ctx, &data)...)
+	resp.Diagnostics.Append(readVolumeState(ctx, client, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -195,7 +233,15 @@ func (r *VolumeResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	resp.Diagnostics.Append(r.readVolumeState(ctx, &data)...)
+	client := r.clients.Client(&data.Metro)
+
+	diags := readVolumeState(ctx, client, &data)
+	if hasNotFound(diags) {
+		// The volume was deleted out-of-band, let Terraform recreate it.
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -215,10 +261,18 @@ func (r *VolumeResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.clients.Client(&plan.Metro)
+
 	if !plan.SizeMB.Equal(state.SizeMB) {
 		newSize := plan.SizeMB.ValueInt64()
 		val := any(newSize)
-		_, err := r.client.UpdateVolumeByUUID(ctx, state.UUID.ValueString(), platform.UpdateVolumeByUUIDRequestBody{
+		_, err := client.UpdateVolumeByUUID(ctx, state.UUID.ValueString(), platform.UpdateVolumeByUUIDRequestBody{
 			Prop:  platform.UpdateVolumeByUUIDRequestBodyPropSize_mb,
 			Op:    platform.UpdateVolumeByUUIDRequestBodyOpSet,
 			Value: &val,
@@ -230,12 +284,20 @@ func (r *VolumeResource) Update(ctx context.Context, req resource.UpdateRequest,
 			)
 			return
 		}
+
+		if err := waitVolumeReady(ctx, client, state.UUID.ValueString(), updateTimeout); err != nil {
+			resp.Diagnostics.AddError(
+				"Client Error",
+				fmt.Sprintf("Volume %s did not become available after resize, got error: %v", state.UUID.ValueString(), err),
+			)
+			return
+		}
 	}
 
 	// Re-read full state after update
 	data := plan
 	data.UUID = state.UUID
-	resp.Diagnostics.Append(r.readVolumeState(ctx, &data)...)
+	resp.Diagnostics.Append(readVolumeState(ctx, client, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -254,7 +316,15 @@ func (r *VolumeResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	_, err := r.client.DeleteVolumeByUUID(ctx, data.UUID.ValueString())
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.clients.Client(&data.Metro)
+
+	_, err := client.DeleteVolumeByUUID(ctx, data.UUID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Client Error",
@@ -262,19 +332,36 @@ func (r *VolumeResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		)
 		return
 	}
+
+	if err := waitVolumeDeleted(ctx, client, data.UUID.ValueString(), deleteTimeout); err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Volume %s was not deleted, got error: %v", data.UUID.ValueString(), err),
+		)
+		return
+	}
+}
+
+// ModifyPlan implements resource.ResourceWithModifyPlan.
+func (r *VolumeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPlanMetro(ctx, r.clients, req, resp)
 }
 
 // ImportState implements resource.ResourceWithImportState.
 func (r *VolumeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("uuid"), req, resp)
+	importStateWithMetro(ctx, path.Root("uuid"), req, resp)
 }
 
 // readVolumeState fetches the current volume state from the API and populates
 // computed fields in the model.
-func (r *VolumeResource) readVolumeState(ctx context.Context, data *VolumeResourceModel) diag.Diagnostics {
+func readVolumeState(ctx context.Context, client platform.Client, data *VolumeResourceModel) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	volResp, err := r.client.GetVolumeByUUID(ctx, data.UUID.ValueString(), true)
+	volResp, err := client.GetVolumeByUUID(ctx, data.UUID.ValueString(), true)
+	if isNotFound(err) {
+		diags.Append(newNotFoundDiagnostic("volume", data.UUID.ValueString()))
+		return diags
+	}
 	if err != nil {
 		diags.AddError(
 			"Client Error",
@@ -328,3 +415,51 @@ func (r *VolumeResource) readVolumeState(ctx context.Context, data *VolumeResour
 
 	return diags
 }
+
+// volumeSettled reports whether a volume is in a state in which it can be
+// used, i.e. it is neither being initialized nor busy with an operation.
+func volumeSettled(state platform.VolumeState) bool {
+	switch state {
+	case platform.VolumeStateAvailable, platform.VolumeStateIdle, platform.VolumeStateMounted:
+		return true
+	}
+	return false
+}
+
+// waitVolumeReady polls a volume until it has settled, and fails early if
+// the platform reports the volume in an error state.
+func waitVolumeReady(ctx context.Context, client platform.Client, uuid string, timeout time.Duration) error {
+	return waitFor(ctx, timeout, func(ctx context.Context) (bool, error) {
+		volResp, err := client.GetVolumeByUUID(ctx, uuid, false)
+		if err != nil {
+			return false, err
+		}
+		if volResp == nil || volResp.Data == nil || len(volResp.Data.Volumes) == 0 {
+			return false, errors.New("empty response from get volume API")
+		}
+		vol := volResp.Data.Volumes[0]
+
+		if vol.State == nil {
+			return false, nil
+		}
+		if *vol.State == platform.VolumeStateError {
+			return false, errors.New("volume is in error state")
+		}
+		return volumeSettled(*vol.State), nil
+	})
+}
+
+// waitVolumeDeleted polls a volume until the platform no longer knows about
+// it.
+func waitVolumeDeleted(ctx context.Context, client platform.Client, uuid string, timeout time.Duration) error {
+	return waitFor(ctx, timeout, func(ctx context.Context) (bool, error) {
+		volResp, err := client.GetVolumeByUUID(ctx, uuid, false)
+		if isNotFound(err) {
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		return volResp == nil || volResp.Data == nil || len(volResp.Data.Volumes) == 0, nil
+	})
+}
@@ -0,0 +1,386 @@
+// Copyright (c) Unikraft GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/unikraft-cloud/terraform-provider-unikraft-cloud/internal/provider/clients"
+	models "github.com/unikraft-cloud/terraform-provider-unikraft-cloud/internal/provider/model"
+
+	"unikraft.com/cloud/sdk/platform"
+)
+
+func NewVolumeAttachmentResource() resource.Resource {
+	return &VolumeAttachmentResource{}
+}
+
+// VolumeAttachmentResource defines the resource implementation.
+type VolumeAttachmentResource struct {
+	clients *clients.Pool
+}
+
+// Ensure VolumeAttachmentResource satisfies various resource interfaces.
+var (
+	_ resource.Resource                = &VolumeAttachmentResource{}
+	_ resource.ResourceWithImportState = &VolumeAttachmentResource{}
+	_ resource.ResourceWithModifyPlan  = &VolumeAttachmentResource{}
+)
+
+// VolumeAttachmentResourceModel describes the resource data model.
+type VolumeAttachmentResourceModel struct {
+	Metro        types.String `tfsdk:"metro"`
+	VolumeUUID   types.String `tfsdk:"volume_uuid"`
+	InstanceUUID types.String `tfsdk:"instance_uuid"`
+	At           types.String `tfsdk:"at"`
+	ReadOnly     types.Bool   `tfsdk:"read_only"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata implements resource.Resource.
+func (r *VolumeAttachmentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_volume_attachment"
+}
+
+// Schema implements resource.Resource.
+func (r *VolumeAttachmentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Attaches a Unikraft Cloud volume to an instance.",
+
+		// Attachments cannot be changed, so every attribute but the timeouts
+		// requires a replacement. The metro does when it is changed.
+		Attributes: map[string]schema.Attribute{
+			"metro": metroAttribute(),
+			"volume_uuid": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "UUID of the volume to attach.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"instance_uuid": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "UUID of the instance to attach the volume to.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"at": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Path at which the volume is mounted in the instance.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"read_only": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Whether the volume is mounted read-only. Defaults to `false`.",
+				PlanModifiers: []planmodifier.Bool{
+					// The prior state is used first, so that leaving the
+					// attribute unset does not replace the attachment.
+					boolplanmodifier.UseStateForUnknown(),
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// Configure implements resource.Resource.
+func (r *VolumeAttachmentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	pool, ok := req.ProviderData.(*clients.Pool)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *clients.Pool, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.clients = pool
+}
+
+// Create implements resource.Resource.
+func (r *VolumeAttachmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data VolumeAttachmentResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.ReadOnly.IsNull() || data.ReadOnly.IsUnknown() {
+		data.ReadOnly = types.BoolValue(false)
+	}
+
+	client := r.clients.Client(&data.Metro)
+
+	instanceUUID := data.InstanceUUID.ValueString()
+	_, err := client.AttachVolumeByUUID(ctx, data.VolumeUUID.ValueString(), platform.AttachVolumeByUUIDRequestBody{
+		AttachTo: platform.BodyInstanceID{Uuid: &instanceUUID},
+		At:       data.At.ValueString(),
+		Readonly: data.ReadOnly.ValueBoolPointer(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Failed to attach volume, got error: %v", err),
+		)
+		return
+	}
+
+	if err := waitVolumeReady(ctx, client, data.VolumeUUID.ValueString(), createTimeout); err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Volume %s did not become available after attaching, got error: %v", data.VolumeUUID.ValueString(), err),
+		)
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read implements resource.Resource.
+func (r *VolumeAttachmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data VolumeAttachmentResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.clients.Client(&data.Metro)
+
+	// The attachment is looked up in the attached_to attribute of the volume,
+	// as when waiting for the volume to be detached.
+	attached, diags := isVolumeAttached(ctx, client, &data)
+	if hasNotFound(diags) {
+		// The volume was deleted out-of-band, and the attachment with it.
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !attached {
+		// The volume was detached out-of-band, let Terraform attach it again.
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// The volume does not report where it is mounted, only the instance does.
+	diags = readVolumeMount(ctx, client, &data)
+	if hasNotFound(diags) {
+		// The instance was deleted out-of-band, and the attachment with it.
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update implements resource.Resource.
+//
+// All attributes but timeouts require a replacement, so only the timeouts and
+// the metro of attachments whose state predates the metro attribute are copied
+// from the plan. Nothing is sent to the API.
+func (r *VolumeAttachmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan VolumeAttachmentResourceModel
+	var state VolumeAttachmentResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Timeouts = plan.Timeouts
+	state.Metro = plan.Metro
+	r.clients.Client(&state.Metro)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Delete implements resource.Resource.
+func (r *VolumeAttachmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data VolumeAttachmentResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.clients.Client(&data.Metro)
+
+	instanceUUID := data.InstanceUUID.ValueString()
+	_, err := client.DetachVolumeByUUID(ctx, data.VolumeUUID.ValueString(), platform.DetachVolumeByUUIDRequestBody{
+		From: &platform.DetachVolumeByUUIDRequestBodyFrom{Uuid: &instanceUUID},
+	})
+	if isNotFound(err) {
+		// Either the volume or the instance is already gone.
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Failed to detach volume, got error: %v", err),
+		)
+		return
+	}
+
+	err = waitFor(ctx, deleteTimeout, func(ctx context.Context) (bool, error) {
+		attached, diags := isVolumeAttached(ctx, client, &data)
+		if hasNotFound(diags) {
+			return true, nil
+		}
+		if diags.HasError() {
+			return false, errors.New(diags.Errors()[0].Detail())
+		}
+		return !attached, nil
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Volume %s was not detached, got error: %v", data.VolumeUUID.ValueString(), err),
+		)
+		return
+	}
+}
+
+// ModifyPlan implements resource.ResourceWithModifyPlan.
+func (r *VolumeAttachmentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPlanMetro(ctx, r.clients, req, resp)
+}
+
+// ImportState implements resource.ResourceWithImportState. The import ID has
+// the format "[<metro>/]<volume_uuid>/<instance_uuid>".
+func (r *VolumeAttachmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	metro, parts, ok := parseImportID(req.ID, 2)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected an import ID of the form [<metro>/]<volume_uuid>/<instance_uuid>, got: %q", req.ID),
+		)
+		return
+	}
+
+	if metro != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("metro"), metro)...)
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("volume_uuid"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("instance_uuid"), parts[1])...)
+}
+
+// isVolumeAttached reports whether the volume of the attachment is attached to
+// its instance, based on the attached_to attribute of the volume.
+func isVolumeAttached(ctx context.Context, client platform.Client, data *VolumeAttachmentResourceModel) (bool, diag.Diagnostics) {
+	vol := VolumeResourceModel{
+		UUID: data.VolumeUUID,
+	}
+
+	diags := readVolumeState(ctx, client, &vol)
+	if diags.HasError() {
+		return false, diags
+	}
+
+	var attachedTo []models.VolumeInstanceModel
+	diags.Append(vol.AttachedTo.ElementsAs(ctx, &attachedTo, false)...)
+	if diags.HasError() {
+		return false, diags
+	}
+
+	return slices.ContainsFunc(attachedTo, func(i models.VolumeInstanceModel) bool {
+		return i.UUID.Equal(data.InstanceUUID)
+	}), diags
+}
+
+// readVolumeMount populates the mount path and mode of the attachment from
+// the volumes of its instance. They are left unchanged if the instance does
+// not report the volume. A notFoundDiagnostic is returned if the instance does
+// not exist.
+func readVolumeMount(ctx context.Context, client platform.Client, data *VolumeAttachmentResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	insResp, err := client.GetInstanceByUUID(ctx, data.InstanceUUID.ValueString(), true)
+	if isNotFound(err) {
+		diags.Append(newNotFoundDiagnostic("instance", data.InstanceUUID.ValueString()))
+		return diags
+	}
+	if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Failed to get instance state, got error: %v", err),
+		)
+		return diags
+	}
+
+	if insResp == nil || insResp.Data == nil || len(insResp.Data.Instances) == 0 {
+		diags.AddError(
+			"Client Error",
+			"Empty response from get instance API",
+		)
+		return diags
+	}
+	ins := insResp.Data.Instances[0]
+
+	for _, v := range ins.Volumes {
+		if v.Uuid == nil || *v.Uuid != data.VolumeUUID.ValueString() {
+			continue
+		}
+		data.At = types.StringPointerValue(v.At)
+		data.ReadOnly = types.BoolValue(v.Readonly != nil && *v.Readonly)
+		break
+	}
+
+	return diags
+}
@@ -0,0 +1,160 @@
+// Copyright (c) Unikraft GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	providerMock "github.com/unikraft-cloud/terraform-provider-unikraft-cloud/internal/provider/mock"
+
+	"unikraft.com/cloud/sdk/platform"
+)
+
+func TestVolumeAttachmentResource_ImportState_InvalidID(t *testing.T) {
+	r := &VolumeAttachmentResource{}
+
+	req := resource.ImportStateRequest{
+		ID: "volume-uuid",
+	}
+	resp := &resource.ImportStateResponse{}
+
+	r.ImportState(context.Background(), req, resp)
+
+	assert.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics.Errors()[0].Summary(), "Invalid Import ID")
+}
+
+func TestVolumeAttachmentResource_Schema_RequiresReplace(t *testing.T) {
+	s := testSchema(t, NewVolumeAttachmentResource()).Schema
+
+	for name, a := range s.Attributes {
+		var descriptions []string
+		switch a := a.(type) {
+		case schema.StringAttribute:
+			for _, m := range a.PlanModifiers {
+				descriptions = append(descriptions, m.Description(context.Background()))
+			}
+		case schema.BoolAttribute:
+			for _, m := range a.PlanModifiers {
+				descriptions = append(descriptions, m.Description(context.Background()))
+			}
+		}
+		d := strings.Join(descriptions, " ")
+		assert.True(t, strings.Contains(d, "recreate") || strings.Contains(d, "new resource"), "%s requires a replacement", name)
+	}
+}
+
+func TestVolumeAttachmentResource_Schema_ReadOnlyUnset(t *testing.T) {
+	r := NewVolumeAttachmentResource()
+	state := testState(t, r)
+	require.False(t, state.SetAttribute(context.Background(), path.Root("read_only"), types.BoolValue(false)).HasError())
+	plan := testPlan(t, r, nil)
+
+	resp := &planmodifier.BoolResponse{PlanValue: types.BoolUnknown()}
+	for _, m := range testSchema(t, r).Schema.Attributes["read_only"].(schema.BoolAttribute).PlanModifiers {
+		m.PlanModifyBool(context.Background(), planmodifier.BoolRequest{
+			Path:        path.Root("read_only"),
+			Config:      tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw},
+			ConfigValue: types.BoolNull(),
+			Plan:        plan,
+			PlanValue:   resp.PlanValue,
+			State:       state,
+			StateValue:  types.BoolValue(false),
+		}, resp)
+	}
+
+	// Leaving read_only unset keeps the attachment.
+	assert.False(t, resp.RequiresReplace)
+	assert.Equal(t, types.BoolValue(false), resp.PlanValue)
+}
+
+// attachedVolume returns a response of the get volume API describing vol-uuid
+// attached to the given instances.
+func attachedVolume(instances ...string) *platform.Response[platform.GetVolumesResponseData] {
+	uuid := "vol-uuid"
+	vol := platform.Volume{Uuid: &uuid}
+	for _, ins := range instances {
+		vol.AttachedTo = append(vol.AttachedTo, platform.VolumeInstanceID{Uuid: &ins})
+	}
+	return &platform.Response[platform.GetVolumesResponseData]{
+		Status: "success",
+		Data:   &platform.GetVolumesResponseData{Volumes: []platform.Volume{vol}},
+	}
+}
+
+// readAttachment runs Read on an attachment of vol-uuid to ins-uuid mounted
+// at /old, with the given responses to the lookups of the volume and of the
+// instance. The instance is only looked up if ins or insErr is set.
+func readAttachment(t *testing.T, vol *platform.Response[platform.GetVolumesResponseData], volErr error, ins *platform.Response[platform.GetInstancesResponseData], insErr error) *resource.ReadResponse {
+	t.Helper()
+
+	mockClient := new(providerMock.PlatformClient)
+	mockClient.On("GetVolumeByUUID", mock.Anything, "vol-uuid", true).Return(vol, volErr)
+	if ins != nil || insErr != nil {
+		mockClient.On("GetInstanceByUUID", mock.Anything, "ins-uuid", true).Return(ins, insErr)
+	}
+
+	r := &VolumeAttachmentResource{clients: testPool(mockClient)}
+	state := testState(t, r)
+	for name, v := range map[string]attr.Value{
+		"volume_uuid":   types.StringValue("vol-uuid"),
+		"instance_uuid": types.StringValue("ins-uuid"),
+		"at":            types.StringValue("/old"),
+		"read_only":     types.BoolValue(false),
+	} {
+		require.False(t, state.SetAttribute(context.Background(), path.Root(name), v).HasError())
+	}
+
+	resp := &resource.ReadResponse{State: state}
+	r.Read(context.Background(), resource.ReadRequest{State: state}, resp)
+	mockClient.AssertExpectations(t)
+	return resp
+}
+
+func TestVolumeAttachmentResource_Read(t *testing.T) {
+	uuid, at, readOnly := "vol-uuid", "/data", true
+	resp := readAttachment(t, attachedVolume("ins-uuid"), nil, &platform.Response[platform.GetInstancesResponseData]{
+		Status: "success",
+		Data: &platform.GetInstancesResponseData{Instances: []platform.Instance{{
+			Volumes: []platform.InstanceVolume{{Uuid: &uuid, At: &at, Readonly: &readOnly}},
+		}}},
+	}, nil)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var got VolumeAttachmentResourceModel
+	require.False(t, resp.State.Get(context.Background(), &got).HasError())
+	assert.Equal(t, "/data", got.At.ValueString())
+	assert.True(t, got.ReadOnly.ValueBool())
+}
+
+func TestVolumeAttachmentResource_Read_Detached(t *testing.T) {
+	resp := readAttachment(t, attachedVolume("other-uuid"), nil, nil, nil)
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	assert.True(t, resp.State.Raw.IsNull(), "the attachment is removed")
+}
+
+func TestVolumeAttachmentResource_Read_VolumeNotFound(t *testing.T) {
+	resp := readAttachment(t, nil, &fakeAPIError{`{"status":"error","errors":[{"status":404}]}`}, nil, nil)
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	assert.True(t, resp.State.Raw.IsNull(), "the attachment is removed")
+}
+
+func TestVolumeAttachmentResource_Read_InstanceNotFound(t *testing.T) {
+	resp := readAttachment(t, attachedVolume("ins-uuid"), nil, nil, &fakeAPIError{`{"status":"error","errors":[{"status":404}]}`})
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	assert.True(t, resp.State.Raw.IsNull(), "the attachment is removed")
+}
@@ -7,11 +7,15 @@ import (
 	"context"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 
 	providerMock "github.com/unikraft-cloud/terraform-provider-unikraft-cloud/internal/provider/mock"
+
+	"unikraft.com/cloud/sdk/platform"
 )
 
 func TestVolumeResource_Metadata(t *testing.T) {
@@ -41,6 +45,14 @@ func TestVolumeResource_Schema(t *testing.T) {
 	assert.Contains(t, resp.Schema.Attributes, "persistent")
 	assert.Contains(t, resp.Schema.Attributes, "created_at")
 	assert.Contains(t, resp.Schema.Attributes, "attached_to")
+	assert.Contains(t, resp.Schema.Blocks, "timeouts")
+}
+
+func TestVolumeSettled(t *testing.T) {
+	assert.True(t, volumeSettled(platform.VolumeStateAvailable))
+	assert.True(t, volumeSettled(platform.VolumeStateMounted))
+	assert.False(t, volumeSettled(platform.VolumeStateInitializing))
+	assert.False(t, volumeSettled(platform.VolumeStateBusy))
 }
 
 func TestVolumeResource_Configure_Success(t *testing.T) {
@@ -109,3 +121,38 @@ func TestVolumeResourceModel_Basic(t *testing.T) {
 	assert.Equal(t, int64(1024), model.SizeMB.ValueInt64())
 	assert.Equal(t, "vol-uuid", model.UUID.ValueString())
 }
+
+func TestVolumeResource_Create_WaitFails(t *testing.T) {
+	ctx := context.Background()
+	mockClient := new(providerMock.PlatformClient)
+	r := &VolumeResource{clients: testPool(mockClient)}
+
+	uuid := "vol-uuid"
+	state := platform.VolumeStateError
+	mockClient.On("CreateVolume", mock.Anything, mock.Anything).Return(&platform.Response[platform.CreateVolumeResponseData]{
+		Status: "success",
+		Data:   &platform.CreateVolumeResponseData{Volumes: []platform.CreateVolumeResponseVolume{{Uuid: &uuid}}},
+	}, nil)
+	mockClient.On("GetVolumeByUUID", mock.Anything, uuid, mock.Anything).Return(&platform.Response[platform.GetVolumesResponseData]{
+		Status: "success",
+		Data:   &platform.GetVolumesResponseData{Volumes: []platform.Volume{{Uuid: &uuid, State: &state}}},
+	}, nil)
+
+	req := resource.CreateRequest{
+		Plan: testPlan(t, r, map[string]attr.Value{
+			"size_mb": types.Int64Value(64),
+			"uuid":    types.StringUnknown(),
+		}),
+	}
+	resp := &resource.CreateResponse{State: testState(t, r)}
+
+	r.Create(ctx, req, resp)
+
+	// The volume is saved, so that Terraform taints it instead of creating
+	// another one on the next apply.
+	assert.True(t, resp.Diagnostics.HasError())
+	var got VolumeResourceModel
+	assert.False(t, resp.State.Get(ctx, &got).HasError())
+	assert.Equal(t, uuid, got.UUID.ValueString())
+	assert.Equal(t, string(platform.VolumeStateError), got.State.ValueString())
+}
@@ -0,0 +1,61 @@
+// Copyright (c) Unikraft GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Default durations of resource operations, used when no value is set in the
+// "timeouts" block of a resource.
+const (
+	defaultCreateTimeout = 10 * time.Minute
+	defaultUpdateTimeout = 10 * time.Minute
+	defaultDeleteTimeout = 10 * time.Minute
+)
+
+// Bounds of the exponential backoff applied between two polls of a waiter.
+const (
+	waitMinInterval = 500 * time.Millisecond
+	waitMaxInterval = 10 * time.Second
+)
+
+// waitCondition is polled by waitFor. It returns true once the awaited
+// condition is met, or an error if the condition can no longer be met.
+type waitCondition func(ctx context.Context) (bool, error)
+
+// waitFor polls cond with an exponential backoff until it reports that the
+// awaited condition is met, returns an error, or the timeout expires.
+func waitFor(ctx context.Context, timeout time.Duration, cond waitCondition) error {
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	interval := waitMinInterval
+	for {
+		done, err := cond(waitCtx)
+		if err != nil {
+			if ctx.Err() == nil && errors.Is(waitCtx.Err(), context.DeadlineExceeded) {
+				return fmt.Errorf("timed out after %s: %w", timeout, err)
+			}
+			return err
+		}
+		if done {
+			return nil
+		}
+
+		select {
+		case <-waitCtx.Done():
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return fmt.Errorf("timed out after %s", timeout)
+		case <-time.After(interval):
+		}
+
+		interval = min(interval*2, waitMaxInterval)
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright (c) Unikraft GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWaitFor_Done(t *testing.T) {
+	calls := 0
+	err := waitFor(context.Background(), time.Minute, func(ctx context.Context) (bool, error) {
+		calls++
+		return calls == 2, nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestWaitFor_Error(t *testing.T) {
+	condErr := errors.New("boom")
+	err := waitFor(context.Background(), time.Minute, func(ctx context.Context) (bool, error) {
+		return false, condErr
+	})
+
+	assert.ErrorIs(t, err, condErr)
+}
+
+func TestWaitFor_Timeout(t *testing.T) {
+	err := waitFor(context.Background(), 10*time.Millisecond, func(ctx context.Context) (bool, error) {
+		return false, nil
+	})
+
+	assert.ErrorContains(t, err, "timed out after 10ms")
+}
+
+func TestWaitFor_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := waitFor(ctx, time.Minute, func(ctx context.Context) (bool, error) {
+		return false, nil
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+}
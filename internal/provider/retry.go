@@ -0,0 +1,187 @@
+// Copyright (c) Unikraft GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// defaultMaxRetries is the number of times a failed request is retried
+	// when max_retries is not set.
+	defaultMaxRetries = 5
+	// defaultMaxRequestsPerSecond is the rate requests are limited to when
+	// max_requests_per_second is not set.
+	defaultMaxRequestsPerSecond = 10
+
+	// minRetryBackoff and maxRetryBackoff bound the exponential backoff
+	// between attempts when the API does not send a Retry-After header.
+	minRetryBackoff = 1 * time.Second
+	maxRetryBackoff = 30 * time.Second
+	// maxRetryAfter bounds the delay requested by the API, so that a bogus
+	// Retry-After header cannot stall an apply.
+	maxRetryAfter = 2 * time.Minute
+)
+
+// retryTransport is an http.RoundTripper which limits the rate of requests
+// sent to the API and retries requests failing with transient errors. It sits
+// below the SDK, so every method of the platform.Client benefits from it.
+//
+// Requests rejected with 429 Too Many Requests are retried regardless of their
+// method, as the API did not act on them. Network errors and 502, 503 and 504
+// responses are only retried for idempotent methods, since a request that
+// created an instance may have been processed before the connection failed.
+type retryTransport struct {
+	next       http.RoundTripper
+	maxRetries int
+	limiter    *rate.Limiter
+}
+
+// newRetryTransport returns a retryTransport sending requests through next.
+// A limit of zero requests per second disables the rate limiter.
+func newRetryTransport(next http.RoundTripper, maxRetries int, requestsPerSecond float64) *retryTransport {
+	limit := rate.Inf
+	burst := 0
+	if requestsPerSecond > 0 {
+		limit = rate.Limit(requestsPerSecond)
+		burst = max(1, int(requestsPerSecond))
+	}
+
+	return &retryTransport{
+		next:       next,
+		maxRetries: maxRetries,
+		limiter:    rate.NewLimiter(limit, burst),
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	for attempt := 0; ; attempt++ {
+		if err := t.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+
+		r := req
+		if attempt > 0 && req.Body != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			r = req.Clone(ctx)
+			r.Body = body
+		}
+
+		resp, err := t.next.RoundTrip(r)
+		// Requests whose body cannot be replayed are not retried.
+		replayable := req.Body == nil || req.GetBody != nil
+		if attempt >= t.maxRetries || !replayable || ctx.Err() != nil || !shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		wait := retryBackoff(attempt, resp)
+
+		fields := map[string]any{
+			"method":  req.Method,
+			"path":    req.URL.Path,
+			"attempt": attempt + 1,
+			"wait":    wait.String(),
+		}
+		if err != nil {
+			fields["error"] = err.Error()
+		} else {
+			fields["status"] = resp.StatusCode
+			// Drain the body so the connection can be reused.
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		tflog.Warn(ctx, "Retrying Unikraft Cloud API request", fields)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// shouldRetry reports whether a request which got resp or err is worth
+// retrying.
+func shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		// Host names which do not exist will not appear on a retry.
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			return false
+		}
+		return isIdempotent(req.Method)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(req.Method)
+	default:
+		return false
+	}
+}
+
+// isIdempotent reports whether sending a request with the given method twice
+// has the same effect as sending it once.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryBackoff returns how long to wait before the next attempt. The delay
+// requested by the Retry-After header of resp takes precedence over the
+// jittered exponential backoff.
+func retryBackoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			return min(d, maxRetryAfter)
+		}
+	}
+
+	backoff := maxRetryBackoff
+	if attempt < 8 {
+		backoff = min(minRetryBackoff<<attempt, maxRetryBackoff)
+	}
+	return backoff/2 + rand.N(backoff/2+1)
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either a
+// number of seconds or an HTTP date, relative to now.
+func parseRetryAfter(v string, now time.Time) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if s, err := strconv.Atoi(v); err == nil {
+		if s < 0 {
+			return 0, false
+		}
+		return time.Duration(s) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(t.Sub(now), 0), true
+	}
+	return 0, false
+}
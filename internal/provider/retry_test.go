@@ -0,0 +1,123 @@
+// Copyright (c) Unikraft GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// retryServer returns a server failing the first failures requests with
+// status, and the number of requests it received so far.
+func retryServer(t *testing.T, status, failures int) (*httptest.Server, *int) {
+	var n int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n++
+		body, _ := io.ReadAll(r.Body)
+		if n <= failures {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(status)
+			return
+		}
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &n
+}
+
+func TestRetryTransport(t *testing.T) {
+	client := &http.Client{Transport: newRetryTransport(http.DefaultTransport, 3, 0)}
+
+	t.Run("idempotent", func(t *testing.T) {
+		srv, n := retryServer(t, http.StatusServiceUnavailable, 2)
+		resp, err := client.Get(srv.URL)
+		if assert.NoError(t, err) {
+			resp.Body.Close()
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		}
+		assert.Equal(t, 3, *n)
+	})
+
+	t.Run("non-idempotent", func(t *testing.T) {
+		srv, n := retryServer(t, http.StatusServiceUnavailable, 2)
+		resp, err := client.Post(srv.URL, "application/json", strings.NewReader("{}"))
+		if assert.NoError(t, err) {
+			resp.Body.Close()
+			assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		}
+		assert.Equal(t, 1, *n)
+	})
+
+	t.Run("too many requests", func(t *testing.T) {
+		srv, n := retryServer(t, http.StatusTooManyRequests, 1)
+		resp, err := client.Post(srv.URL, "application/json", strings.NewReader(`{"name":"test"}`))
+		if assert.NoError(t, err) {
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, `{"name":"test"}`, string(body), "the body is replayed")
+		}
+		assert.Equal(t, 2, *n)
+	})
+
+	t.Run("max retries", func(t *testing.T) {
+		srv, n := retryServer(t, http.StatusTooManyRequests, 10)
+		resp, err := client.Get(srv.URL)
+		if assert.NoError(t, err) {
+			resp.Body.Close()
+			assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		}
+		assert.Equal(t, 4, *n)
+	})
+
+	t.Run("client error", func(t *testing.T) {
+		srv, n := retryServer(t, http.StatusNotFound, 1)
+		resp, err := client.Get(srv.URL)
+		if assert.NoError(t, err) {
+			resp.Body.Close()
+			assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		}
+		assert.Equal(t, 1, *n)
+	})
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	d, ok := parseRetryAfter("7", now)
+	assert.True(t, ok)
+	assert.Equal(t, 7*time.Second, d)
+
+	d, ok = parseRetryAfter(now.Add(30*time.Second).Format(http.TimeFormat), now)
+	assert.True(t, ok)
+	assert.Equal(t, 30*time.Second, d)
+
+	d, ok = parseRetryAfter(now.Add(-time.Minute).Format(http.TimeFormat), now)
+	assert.True(t, ok)
+	assert.Zero(t, d)
+
+	_, ok = parseRetryAfter("", now)
+	assert.False(t, ok)
+	_, ok = parseRetryAfter("-1", now)
+	assert.False(t, ok)
+	_, ok = parseRetryAfter("soon", now)
+	assert.False(t, ok)
+}
+
+func TestRetryBackoff(t *testing.T) {
+	for attempt := range 12 {
+		d := retryBackoff(attempt, nil)
+		assert.GreaterOrEqual(t, d, minRetryBackoff/2)
+		assert.LessOrEqual(t, d, maxRetryBackoff)
+	}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"3600"}}}
+	assert.Equal(t, maxRetryAfter, retryBackoff(0, resp))
+}
@@ -0,0 +1,76 @@
+// Copyright (c) Unikraft GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/unikraft-cloud/terraform-provider-unikraft-cloud/internal/provider/clients"
+
+	"unikraft.com/cloud/sdk/platform"
+)
+
+// validateCredentials looks up the quotas of the account the client is
+// authenticated as, in order to report a wrong token, metro or endpoint when
+// the provider is configured rather than on the first request of a resource.
+// The endpoint is empty if the well-known URL of the metro is used.
+func validateCredentials(ctx context.Context, client platform.Client, metro, endpoint string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	_, err := client.GetUser(ctx)
+	if err == nil {
+		return diags
+	}
+
+	var dnsErr *net.DNSError
+	var urlErr *url.Error
+	switch {
+	case errors.As(err, &dnsErr) && dnsErr.IsNotFound && endpoint == "":
+		diags.AddAttributeError(
+			path.Root("metro"),
+			"Unknown Unikraft Cloud API Metro",
+			fmt.Sprintf("The Unikraft Cloud API metro %q does not exist, as %s cannot be resolved. "+
+				"Set the metro to one of the metros of Unikraft Cloud, e.g. %q.", metro, dnsErr.Name, platform.DefaultMetro),
+		)
+	case errors.As(err, &urlErr):
+		attr := path.Root("endpoint")
+		if endpoint == "" {
+			attr = path.Root("metro")
+		}
+		diags.AddAttributeError(
+			attr,
+			"Unreachable Unikraft Cloud API",
+			fmt.Sprintf("The provider cannot reach the Unikraft Cloud API of metro %q, got error: %v\n\n"+
+				"Check the endpoint and the network connection, or set skip_credentials_validation to plan without access to the API.", metro, err),
+		)
+	case isUnauthorized(err):
+		diags.AddAttributeError(
+			path.Root("token"),
+			"Invalid Unikraft Cloud API Token",
+			fmt.Sprintf("The Unikraft Cloud API of metro %q rejected the token, got error: %v\n\n"+
+				"Check that the token is valid and belongs to an account with access to the metro.", metro, err),
+		)
+	default:
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Failed to validate the Unikraft Cloud API credentials, got error: %v", err),
+		)
+	}
+
+	return diags
+}
+
+// isUnauthorized reports whether err is a response of the API rejecting the
+// credentials of the request.
+func isUnauthorized(err error) bool {
+	body, ok := clients.DecodeAPIError(err)
+	return ok && body.HasStatus(http.StatusUnauthorized, http.StatusForbidden)
+}
@@ -0,0 +1,94 @@
+// Copyright (c) Unikraft GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"unikraft.com/cloud/sdk/platform"
+)
+
+func TestValidateCredentials(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Header.Get("Authorization") != "Bearer valid" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"status":"error","message":"invalid token","errors":[{"status":401}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"success","data":{"quotas":[{}]}}`))
+	}))
+	defer srv.Close()
+
+	newClient := func(token string) platform.Client {
+		return platform.NewClient(
+			platform.WithToken(token),
+			platform.WithHTTPClient(srv.Client()),
+		).WithMetro(srv.URL)
+	}
+
+	diags := validateCredentials(context.Background(), newClient("valid"), "fra0", srv.URL)
+	assert.False(t, diags.HasError())
+
+	diags = validateCredentials(context.Background(), newClient("invalid"), "fra0", srv.URL)
+	if assert.Len(t, diags, 1) {
+		assert.Equal(t, "Invalid Unikraft Cloud API Token", diags[0].Summary())
+	}
+}
+
+func TestValidateCredentials_Unreachable(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	endpoint := srv.URL
+	srv.Close()
+
+	client := platform.NewClient(platform.WithToken("valid")).WithMetro(endpoint)
+	diags := validateCredentials(context.Background(), client, "fra0", endpoint)
+	if assert.Len(t, diags, 1) {
+		assert.Equal(t, "Unreachable Unikraft Cloud API", diags[0].Summary())
+	}
+}
+
+func TestValidateCredentials_UnknownMetro(t *testing.T) {
+	client := platform.NewClient(
+		platform.WithToken("valid"),
+		platform.WithDefaultMetro("xyz0"),
+		platform.WithHTTPClient(&http.Client{
+			Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				return nil, &net.DNSError{Err: "no such host", Name: req.URL.Hostname(), IsNotFound: true}
+			}),
+		}),
+	)
+
+	diags := validateCredentials(context.Background(), client, "xyz0", "")
+	if assert.Len(t, diags, 1) {
+		assert.Equal(t, "Unknown Unikraft Cloud API Metro", diags[0].Summary())
+		assert.Contains(t, diags[0].Detail(), "api.xyz0.kraft.cloud")
+	}
+}
+
+func TestIsUnauthorized(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"status":"error","message":"invalid token","errors":[{"status":401}]}`))
+	}))
+	defer srv.Close()
+
+	client := platform.NewClient(
+		platform.WithToken("invalid"),
+		platform.WithHTTPClient(srv.Client()),
+	).WithMetro(srv.URL)
+	_, err := client.GetUser(context.Background())
+
+	// The result does not depend on the body of the response having been
+	// read before.
+	assert.True(t, isUnauthorized(err))
+	assert.True(t, isUnauthorized(err))
+}